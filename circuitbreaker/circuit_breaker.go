@@ -0,0 +1,282 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/reeceappling/goUtils/v2/errorreference"
+	"github.com/reeceappling/goUtils/v2/logging"
+)
+
+type State int
+
+const (
+	StateClosed   State = iota // calls pass through and are counted
+	StateOpen                  // calls fail fast with errorreference.ErrorCircuitOpen
+	StateHalfOpen              // a limited number of trial calls decide whether to close or reopen
+)
+
+func (state State) String() string {
+	switch state {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Settings configures a Breaker. Zero values are replaced with the defaults noted on each field
+type Settings struct {
+	Name             string
+	Window           time.Duration    // period the failure rate is measured over, default 10s
+	MinRequests      int              // calls needed in the window before the breaker can open, default 10
+	FailureRate      float64          // fraction of failed calls in the window that opens the breaker, default 0.5
+	Cooldown         time.Duration    // time spent open before allowing trial calls, default 5s
+	HalfOpenMaxCalls int              // concurrent trial calls while half-open, all must succeed to close, default 1
+	IsFailure        func(error) bool // which errors count against the dependency, default anything but cancellations
+
+	// OnStateChange is called after every transition, in addition to the transition being logged
+	OnStateChange func(ctx context.Context, name string, from, to State)
+}
+
+const numBuckets = 10
+
+// bucket counts the calls finished in one slice of the window
+type bucket struct {
+	start     time.Time
+	successes int
+	failures  int
+}
+
+// Breaker is a closed/open/half-open circuit breaker over a sliding failure-rate window.
+// It is safe for concurrent use.
+type Breaker struct {
+	settings Settings
+	now      func() time.Time
+
+	mut              sync.Mutex
+	state            State
+	generation       uint64 // incremented on every transition, so results from calls of a previous state are ignored
+	openedAt         time.Time
+	halfOpenInFlight int
+	halfOpenPassed   int
+	buckets          [numBuckets]bucket
+}
+
+func New(settings Settings) *Breaker {
+	if settings.Window <= 0 {
+		settings.Window = 10 * time.Second
+	}
+	if settings.MinRequests <= 0 {
+		settings.MinRequests = 10
+	}
+	if settings.FailureRate <= 0 {
+		settings.FailureRate = 0.5
+	}
+	if settings.Cooldown <= 0 {
+		settings.Cooldown = 5 * time.Second
+	}
+	if settings.HalfOpenMaxCalls <= 0 {
+		settings.HalfOpenMaxCalls = 1
+	}
+	if settings.IsFailure == nil {
+		settings.IsFailure = isFailure
+	}
+	return &Breaker{settings: settings, now: time.Now}
+}
+
+func isFailure(err error) bool {
+	return err != nil && errorreference.Classify(err) != errorreference.ClassCancelled
+}
+
+func (b *Breaker) Name() string {
+	return b.settings.Name
+}
+
+// IsFailure reports whether err counts against the dependency, for callers using Allow directly
+func (b *Breaker) IsFailure(err error) bool {
+	return b.settings.IsFailure(err)
+}
+
+func (b *Breaker) State() State {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	return b.state
+}
+
+type transition struct {
+	from, to State
+}
+
+// outcome is how a finished call is counted
+type outcome int
+
+const (
+	outcomeSuccess outcome = iota
+	outcomeFailure
+	outcomeIgnored // the caller gave up, which says nothing about the dependency
+)
+
+// Allow asks to make one call. When the breaker is open it returns errorreference.ErrorCircuitOpen.
+// Otherwise, report must be called exactly once with whether the call failed.
+func (b *Breaker) Allow(ctx context.Context) (report func(failed bool), err error) {
+	record, err := b.allow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return func(failed bool) {
+		if failed {
+			record(outcomeFailure)
+		} else {
+			record(outcomeSuccess)
+		}
+	}, nil
+}
+
+func (b *Breaker) allow(ctx context.Context) (report func(outcome), err error) {
+	b.mut.Lock()
+	var changes []transition
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.settings.Cooldown {
+		changes = append(changes, b.setState(StateHalfOpen))
+	}
+	switch {
+	case b.state == StateOpen:
+		err = errorreference.ErrorCircuitOpen
+	case b.state == StateHalfOpen && b.halfOpenInFlight >= b.settings.HalfOpenMaxCalls:
+		err = errorreference.ErrorCircuitOpen
+	case b.state == StateHalfOpen:
+		b.halfOpenInFlight++
+	}
+	generation := b.generation
+	b.mut.Unlock()
+	b.notify(ctx, changes)
+
+	if err != nil {
+		return nil, err
+	}
+	return func(result outcome) {
+		b.notify(ctx, b.record(generation, result))
+	}, nil
+}
+
+func (b *Breaker) record(generation uint64, result outcome) []transition {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	if generation != b.generation {
+		return nil
+	}
+
+	if b.state == StateHalfOpen {
+		b.halfOpenInFlight--
+		switch result {
+		case outcomeIgnored:
+			return nil
+		case outcomeFailure:
+			return []transition{b.setState(StateOpen)}
+		}
+		b.halfOpenPassed++
+		if b.halfOpenPassed >= b.settings.HalfOpenMaxCalls {
+			return []transition{b.setState(StateClosed)}
+		}
+		return nil
+	}
+
+	if result == outcomeIgnored {
+		return nil
+	}
+	now := b.now()
+	current := b.bucketAt(now)
+	if result == outcomeFailure {
+		current.failures++
+	} else {
+		current.successes++
+	}
+
+	total, failures := 0, 0
+	for _, counted := range b.buckets {
+		if now.Sub(counted.start) < b.settings.Window {
+			total += counted.successes + counted.failures
+			failures += counted.failures
+		}
+	}
+	if total >= b.settings.MinRequests && float64(failures)/float64(total) >= b.settings.FailureRate {
+		return []transition{b.setState(StateOpen)}
+	}
+	return nil
+}
+
+// bucketAt returns the bucket covering t, clearing it first if it last covered an older slice of time
+func (b *Breaker) bucketAt(t time.Time) *bucket {
+	width := max(b.settings.Window/numBuckets, time.Nanosecond) // windows under numBuckets nanoseconds would divide by zero
+	start := t.Truncate(width)
+	current := &b.buckets[(start.UnixNano()/int64(width))%numBuckets]
+	if !current.start.Equal(start) {
+		*current = bucket{start: start}
+	}
+	return current
+}
+
+// setState must be called while holding the lock
+func (b *Breaker) setState(state State) transition {
+	change := transition{from: b.state, to: state}
+	b.state = state
+	b.generation++
+	b.halfOpenInFlight, b.halfOpenPassed = 0, 0
+	switch state {
+	case StateOpen:
+		b.openedAt = b.now()
+	case StateClosed:
+		b.buckets = [numBuckets]bucket{}
+	}
+	return change
+}
+
+func (b *Breaker) notify(ctx context.Context, changes []transition) {
+	for _, change := range changes {
+		logging.GetSugaredLogger(ctx).Warnw("circuit breaker changed state",
+			"breaker", b.settings.Name, "from", change.from.String(), "to", change.to.String())
+		if b.settings.OnStateChange != nil {
+			b.settings.OnStateChange(ctx, b.settings.Name, change.from, change.to)
+		}
+	}
+}
+
+// Do runs f through the breaker, returning errorreference.ErrorCircuitOpen without calling f when the breaker is open
+func (b *Breaker) Do(ctx context.Context, f func(context.Context) error) error {
+	_, err := Execute(ctx, b, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, f(ctx)
+	})
+	return err
+}
+
+// Execute runs f through the breaker, returning errorreference.ErrorCircuitOpen without calling f when the breaker is open.
+// Cancelled calls that IsFailure does not count are ignored, rather than counted as successes.
+func Execute[T any](ctx context.Context, b *Breaker, f func(context.Context) (T, error)) (t T, err error) {
+	report, err := b.allow(ctx)
+	if err != nil {
+		return t, err
+	}
+	result := outcomeFailure // a panicking f still releases its half-open slot
+	defer func() { report(result) }()
+	t, err = f(ctx)
+	switch {
+	case b.IsFailure(err):
+		result = outcomeFailure
+	case err != nil && errorreference.Classify(err) == errorreference.ClassCancelled:
+		result = outcomeIgnored
+	default:
+		result = outcomeSuccess
+	}
+	return t, err
+}
+
+// IsOpen is true if err came from an open breaker
+func IsOpen(err error) bool {
+	return errors.Is(err, errorreference.ErrorCircuitOpen)
+}
@@ -0,0 +1,131 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"github.com/reeceappling/goUtils/v2/errorreference"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (clock *fakeClock) now() time.Time {
+	return clock.t
+}
+
+func newTestBreaker(settings Settings) (*Breaker, *fakeClock, *[]State) {
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	var changes []State
+	settings.OnStateChange = func(_ context.Context, _ string, _, to State) {
+		changes = append(changes, to)
+	}
+	b := New(settings)
+	b.now = clock.now
+	return b, clock, &changes
+}
+
+func TestBreaker(t *testing.T) {
+	ctx := context.Background()
+	errTest := errors.New("dependency down")
+	fail := func(context.Context) error { return errTest }
+	succeed := func(context.Context) error { return nil }
+
+	t.Run("opens once the failure rate is reached with enough requests", func(t *testing.T) {
+		b, _, changes := newTestBreaker(Settings{MinRequests: 4, FailureRate: 0.5})
+		assert.NoError(t, b.Do(ctx, succeed))
+		assert.ErrorIs(t, b.Do(ctx, fail), errTest)
+		assert.NoError(t, b.Do(ctx, succeed))
+		assert.Equal(t, StateClosed, b.State(), "not enough requests yet")
+		assert.ErrorIs(t, b.Do(ctx, fail), errTest)
+		assert.Equal(t, StateOpen, b.State())
+		assert.Equal(t, []State{StateOpen}, *changes)
+
+		called := false
+		err := b.Do(ctx, func(context.Context) error { called = true; return nil })
+		assert.ErrorIs(t, err, errorreference.ErrorCircuitOpen)
+		assert.True(t, IsOpen(err))
+		assert.False(t, called)
+	})
+
+	t.Run("old failures leave the window", func(t *testing.T) {
+		b, clock, _ := newTestBreaker(Settings{MinRequests: 2, Window: time.Second})
+		assert.Error(t, b.Do(ctx, fail))
+		clock.t = clock.t.Add(2 * time.Second)
+		assert.Error(t, b.Do(ctx, fail))
+		assert.Equal(t, StateClosed, b.State())
+		assert.Error(t, b.Do(ctx, fail))
+		assert.Equal(t, StateOpen, b.State())
+	})
+
+	t.Run("cancellations are not failures by default", func(t *testing.T) {
+		b, _, _ := newTestBreaker(Settings{MinRequests: 1})
+		assert.ErrorIs(t, b.Do(ctx, func(context.Context) error { return context.Canceled }), context.Canceled)
+		assert.Equal(t, StateClosed, b.State())
+	})
+
+	t.Run("windows shorter than the bucket count still work", func(t *testing.T) {
+		b, _, _ := newTestBreaker(Settings{MinRequests: 1, Window: 5 * time.Nanosecond})
+		assert.NotPanics(t, func() { _ = b.Do(ctx, fail) })
+		assert.Equal(t, StateOpen, b.State())
+	})
+
+	t.Run("cancelled half-open trials are ignored", func(t *testing.T) {
+		b, clock, changes := newTestBreaker(Settings{MinRequests: 1, Cooldown: time.Second})
+		assert.Error(t, b.Do(ctx, fail))
+		clock.t = clock.t.Add(time.Second)
+
+		assert.ErrorIs(t, b.Do(ctx, func(context.Context) error { return context.Canceled }), context.Canceled)
+		assert.Equal(t, StateHalfOpen, b.State(), "a cancelled trial neither closes nor reopens the breaker")
+		assert.NoError(t, b.Do(ctx, succeed), "the trial slot was released")
+		assert.Equal(t, []State{StateOpen, StateHalfOpen, StateClosed}, *changes)
+	})
+
+	t.Run("half-open closes on success and reopens on failure", func(t *testing.T) {
+		b, clock, changes := newTestBreaker(Settings{MinRequests: 1, Cooldown: time.Minute})
+		assert.Error(t, b.Do(ctx, fail))
+		assert.Equal(t, StateOpen, b.State())
+
+		clock.t = clock.t.Add(time.Minute)
+		assert.ErrorIs(t, b.Do(ctx, fail), errTest)
+		assert.Equal(t, StateOpen, b.State())
+
+		clock.t = clock.t.Add(time.Minute)
+		assert.NoError(t, b.Do(ctx, succeed))
+		assert.Equal(t, StateClosed, b.State())
+		assert.Equal(t, []State{StateOpen, StateHalfOpen, StateOpen, StateHalfOpen, StateClosed}, *changes)
+	})
+
+	t.Run("half-open limits concurrent trial calls", func(t *testing.T) {
+		b, clock, _ := newTestBreaker(Settings{MinRequests: 1, Cooldown: time.Second})
+		assert.Error(t, b.Do(ctx, fail))
+		clock.t = clock.t.Add(time.Second)
+
+		report, err := b.Allow(ctx)
+		assert.NoError(t, err)
+		_, err = b.Allow(ctx)
+		assert.ErrorIs(t, err, errorreference.ErrorCircuitOpen)
+		report(false)
+		assert.Equal(t, StateClosed, b.State())
+	})
+
+	t.Run("panics release the half-open slot", func(t *testing.T) {
+		b, clock, _ := newTestBreaker(Settings{MinRequests: 1, Cooldown: time.Second})
+		assert.Error(t, b.Do(ctx, fail))
+		clock.t = clock.t.Add(time.Second)
+		assert.Panics(t, func() {
+			_ = b.Do(ctx, func(context.Context) error { panic("boom") })
+		})
+		assert.Equal(t, StateOpen, b.State())
+	})
+
+	t.Run("Execute returns f's value", func(t *testing.T) {
+		b, _, _ := newTestBreaker(Settings{})
+		out, err := Execute(ctx, b, func(context.Context) (int, error) { return 42, nil })
+		assert.NoError(t, err)
+		assert.Equal(t, 42, out)
+	})
+}
@@ -0,0 +1,73 @@
+package date
+
+import (
+	"time"
+
+	"github.com/reeceappling/goUtils/v2/utils"
+)
+
+// Calendar decides which days are business days
+type Calendar interface {
+	IsBusinessDay(d Date) bool
+}
+
+// CalendarFunc lets a plain function be used as a Calendar
+type CalendarFunc func(d Date) bool
+
+func (f CalendarFunc) IsBusinessDay(d Date) bool {
+	return f(d)
+}
+
+// HolidayCalendar has business days on every day that is neither a weekend day nor a holiday
+type HolidayCalendar struct {
+	Holidays utils.Set[Date]
+	Weekend  []time.Weekday // defaults to Saturday and Sunday when empty
+}
+
+// NewHolidayCalendar returns a Saturday and Sunday weekend calendar with the given holidays
+func NewHolidayCalendar(holidays ...Date) HolidayCalendar {
+	return HolidayCalendar{Holidays: utils.SetOf(holidays)}
+}
+
+func (c HolidayCalendar) IsBusinessDay(d Date) bool {
+	weekend := c.Weekend
+	if len(weekend) == 0 {
+		weekend = []time.Weekday{time.Saturday, time.Sunday}
+	}
+	for _, day := range weekend {
+		if d.Weekday() == day {
+			return false
+		}
+	}
+	return !c.Holidays.Contains(d)
+}
+
+// NextBusinessDay is the first business day after d. The calendar must have at least one business day in every week
+func NextBusinessDay(calendar Calendar, d Date) Date {
+	for d = d.AddDays(1); !calendar.IsBusinessDay(d); d = d.AddDays(1) {
+	}
+	return d
+}
+
+// PreviousBusinessDay is the last business day before d. The calendar must have at least one business day in every week
+func PreviousBusinessDay(calendar Calendar, d Date) Date {
+	for d = d.AddDays(-1); !calendar.IsBusinessDay(d); d = d.AddDays(-1) {
+	}
+	return d
+}
+
+// BusinessDaysBetween counts the business days after a up to and including b, negative if b is before a.
+// Like DaysBetween, Monday to the following Friday is 4 days
+func BusinessDaysBetween(calendar Calendar, a, b Date) int {
+	sign := 1
+	if b.Before(a) {
+		a, b, sign = b, a, -1
+	}
+	count := 0
+	for d := range (DateRange{Start: a.AddDays(1), End: b}).All() {
+		if calendar.IsBusinessDay(d) {
+			count++
+		}
+	}
+	return sign * count
+}
@@ -0,0 +1,52 @@
+package date
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendar(t *testing.T) {
+	// 2025-01-01 is a Wednesday and a holiday
+	calendar := NewHolidayCalendar(MustParse("2025-01-01"))
+
+	t.Run("IsBusinessDay", func(t *testing.T) {
+		tests := map[string]bool{"2024-12-31": true, "2025-01-01": false, "2025-01-02": true, "2025-01-04": false, "2025-01-05": false}
+		for d, exp := range tests {
+			assert.Equal(t, exp, calendar.IsBusinessDay(MustParse(d)), d)
+		}
+		fridaySaturday := HolidayCalendar{Weekend: []time.Weekday{time.Friday, time.Saturday}}
+		assert.True(t, fridaySaturday.IsBusinessDay(MustParse("2025-01-05")))
+		assert.False(t, fridaySaturday.IsBusinessDay(MustParse("2025-01-03")))
+	})
+	t.Run("NextBusinessDay", func(t *testing.T) {
+		tests := map[string]string{
+			"2024-12-31": "2025-01-02", // skips the holiday
+			"2025-01-03": "2025-01-06", // skips the weekend
+			"2025-01-04": "2025-01-06",
+			"2025-01-06": "2025-01-07",
+		}
+		for from, exp := range tests {
+			assert.Equal(t, MustParse(exp), NextBusinessDay(calendar, MustParse(from)), from)
+		}
+		assert.Equal(t, MustParse("2024-12-31"), PreviousBusinessDay(calendar, MustParse("2025-01-02")))
+	})
+	t.Run("BusinessDaysBetween", func(t *testing.T) {
+		tests := []struct {
+			a, b string
+			exp  int
+		}{
+			{"2025-01-06", "2025-01-10", 4},
+			{"2025-01-06", "2025-01-06", 0},
+			{"2025-01-03", "2025-01-06", 1},
+			{"2024-12-30", "2025-01-06", 4},
+			{"2025-01-10", "2025-01-06", -4},
+		}
+		for _, test := range tests {
+			assert.Equal(t, test.exp, BusinessDaysBetween(calendar, MustParse(test.a), MustParse(test.b)), "%s to %s", test.a, test.b)
+		}
+		everyDay := CalendarFunc(func(Date) bool { return true })
+		assert.Equal(t, 7, BusinessDaysBetween(everyDay, MustParse("2025-01-06"), MustParse("2025-01-13")))
+	})
+}
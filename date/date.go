@@ -1,63 +1,206 @@
 package date
 
-import "time"
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
 
-type date struct {
-	year  year
-	month month
-	day   int
+// Layout is the YYYY-MM-DD format used to parse and format a Date
+const Layout = time.DateOnly
+
+// Date is a calendar day without a time or time zone.
+// The zero value Date{} is not a real day: it prints as "0000-00-00" and is only useful as "no date", see IsZero.
+// It is written as an empty string in text and JSON, and as NULL in SQL, and read back from those.
+// Dates are comparable with ==
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// New returns the Date of the given year, month and day, normalising out of range values like time.Date does,
+// e.g. New(2025, time.February, 30) is March 2nd
+func New(year int, month time.Month, day int) Date {
+	return Of(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
 }
 
-func (date date) earliest() time.Time {
-	return time.Date(int(date.year), time.Month(date.month), date.day, 0, 0, 0, 0, nil)
+// Of returns the day t falls on in t's own time zone
+func Of(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
 }
 
-func (date date) latest() time.Time {
-	return date.earliest().Add((24 * time.Hour) - time.Second)
+// OfIn returns the day t falls on in loc, e.g. the same instant may be Monday in UTC but Sunday in New York
+func OfIn(t time.Time, loc *time.Location) Date {
+	return Of(t.In(locationOrUTC(loc)))
 }
-func todayEarliesttime(utc bool) time.Time {
-	return toDate(time.Now()).earliest()
+
+// Today returns the current day in loc
+func Today(loc *time.Location) Date {
+	return OfIn(time.Now(), loc)
+}
+
+// Parse reads a YYYY-MM-DD date
+func Parse(s string) (Date, error) {
+	t, err := time.Parse(Layout, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("parsing date %q: %w", s, err)
+	}
+	return Of(t), nil
 }
 
-func toDate(t time.Time) date {
-	return date{
-		year:  year(t.Year()),
-		month: month(t.Month()),
-		day:   t.Day(),
+// MustParse is Parse that panics on error, for constants and tests
+func MustParse(s string) Date {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
 	}
+	return d
+}
+
+func locationOrUTC(loc *time.Location) *time.Location {
+	if loc == nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// String formats the date as YYYY-MM-DD
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// Earliest is the first instant of the day in loc (UTC if nil)
+func (d Date) Earliest(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, locationOrUTC(loc))
+}
+
+// Latest is the last nanosecond of the day in loc (UTC if nil), allowing for days that are not 24 hours long
+func (d Date) Latest(loc *time.Location) time.Time {
+	return d.AddDays(1).Earliest(loc).Add(-time.Nanosecond)
+}
+
+// utc is midnight UTC, which has no daylight saving changes so whole days are always 24 hours apart
+func (d Date) utc() time.Time {
+	return d.Earliest(time.UTC)
+}
+
+func (d Date) AddDays(days int) Date {
+	return New(d.Year, d.Month, d.Day+days)
+}
+
+// AddMonths moves by whole months, clamping the day to the end of shorter months,
+// e.g. January 31st plus one month is February 28th (or 29th)
+func (d Date) AddMonths(months int) Date {
+	first := New(d.Year, d.Month+time.Month(months), 1)
+	first.Day = min(d.Day, DaysInMonth(month(first.Month), year(first.Year).isLeapYear()))
+	return first
+}
+
+// AddYears is AddMonths for whole years, so February 29th plus one year is February 28th
+func (d Date) AddYears(years int) Date {
+	return d.AddMonths(12 * years)
 }
 
-func (today date) daysUntil(target date) int {
+// DaysBetween is the number of days from a to b, negative if b is before a
+func DaysBetween(a, b Date) int {
+	const secondsPerDay = 24 * 60 * 60
+	return int((b.utc().Unix() - a.utc().Unix()) / secondsPerDay) // not Sub, whose Duration overflows after 292 years
+}
+
+// DaysUntil is the number of days from d to target, negative if target is in the past
+func (d Date) DaysUntil(target Date) int {
+	return DaysBetween(d, target)
+}
+
+// DaysSince is the number of days from target to d, negative if target is in the future
+func (d Date) DaysSince(target Date) int {
+	return DaysBetween(target, d)
+}
+
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+// Compare returns -1, 0 or +1 when d is before, equal to or after other
+func (d Date) Compare(other Date) int {
+	return d.utc().Compare(other.utc())
+}
+
+func (d Date) Weekday() time.Weekday {
+	return d.utc().Weekday()
+}
 
-	//order := []date{toDate(todayEarliesttime(false)), target} // TODO: does nothing, fix
-	//
-	//yearsApart := target.year - today.year
-	//if yearsApart < 0 {
-	//	yearsApart = -yearsApart
-	//}
-	return 0 // TODO; del
+// ISOWeek returns the ISO 8601 year and week number, where week 1 is the week containing the year's first Thursday
+func (d Date) ISOWeek() (year, week int) {
+	return d.utc().ISOWeek()
 }
 
-func (today date) daysSince(target date) int {
-	return target.daysUntil(today)
+// YearDay is the day of the year, from 1 to 365 or 366
+func (d Date) YearDay() int {
+	return d.utc().YearDay()
 }
 
-func (date date) daysInYearUntilToday() int {
-	daysPassedInPrevMonths, daysPassedThisMonth := 0, date.day-1
-	for m := 1; m < int(date.month); m++ {
-		daysPassedInPrevMonths = daysPassedInPrevMonths + DaysInMonth(date.month, date.year.isLeapYear())
+func (d Date) IsLeapYear() bool {
+	return year(d.Year).isLeapYear()
+}
+
+// MarshalText formats the date as YYYY-MM-DD, which encoding/json also uses to write it as a JSON string.
+// The zero Date is an empty string
+func (d Date) MarshalText() ([]byte, error) {
+	if d.IsZero() {
+		return []byte{}, nil
 	}
-	return daysPassedInPrevMonths + daysPassedThisMonth
+	return []byte(d.String()), nil
 }
 
-//func (date date) daysInYearAfterToday() int { // TODO: reenable
-//	daysLeft := DaysInMonth(date.month, date.year.isLeapYear()) - date.day // Days left in this month
-//	daysInNextMonth, daysPassedThisMonth := 0, date.day-1
-//	for m := int(date.month) + 1; m < 13; m++ {
-//		daysLeft = daysLeft + DaysInMonth(month(m), date.year.isLeapYear())
-//	}
-//	return daysPassedInPrevMonths + daysPassedThisMonth
-//}
+// UnmarshalText reads a YYYY-MM-DD date, or an empty string as the zero Date
+func (d *Date) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := Parse(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Value stores the date as midnight UTC, which SQL drivers write to DATE columns. The zero Date is NULL
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.utc(), nil
+}
+
+// Scan reads a DATE column returned as a time.Time or a YYYY-MM-DD string. NULL becomes the zero Date
+func (d *Date) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*d = Date{}
+	case time.Time:
+		*d = Of(v)
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a date", src)
+	}
+	return nil
+}
 
 type year int
 
@@ -68,7 +211,7 @@ func (yr year) isLeapYear() bool {
 		return false
 	}
 	if yr%100 == 0 {
-		return false
+		return yr%400 == 0
 	}
 	return true
 }
@@ -0,0 +1,133 @@
+package date
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDate(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	t.Run("New normalises", func(t *testing.T) {
+		assert.Equal(t, Date{2025, time.March, 2}, New(2025, time.February, 30))
+		assert.Equal(t, Date{2024, time.December, 31}, New(2025, time.January, 0))
+	})
+	t.Run("OfIn", func(t *testing.T) {
+		instant := time.Date(2025, time.March, 3, 2, 0, 0, 0, time.UTC)
+		assert.Equal(t, Date{2025, time.March, 3}, OfIn(instant, nil))
+		assert.Equal(t, Date{2025, time.March, 2}, OfIn(instant, newYork))
+	})
+	t.Run("Earliest and Latest", func(t *testing.T) {
+		d := Date{2025, time.March, 9} // daylight saving starts in New York, so the day is 23 hours long
+		assert.Equal(t, time.Date(2025, time.March, 9, 0, 0, 0, 0, time.UTC), d.Earliest(nil))
+		assert.Equal(t, time.Date(2025, time.March, 9, 23, 59, 59, 999999999, time.UTC), d.Latest(nil))
+		assert.Equal(t, 23*time.Hour-time.Nanosecond, d.Latest(newYork).Sub(d.Earliest(newYork)))
+		assert.Equal(t, d, OfIn(d.Latest(newYork), newYork))
+	})
+	t.Run("AddMonths clamps", func(t *testing.T) {
+		tests := []struct {
+			from   Date
+			months int
+			exp    Date
+		}{
+			{Date{2025, time.January, 31}, 1, Date{2025, time.February, 28}},
+			{Date{2024, time.January, 31}, 1, Date{2024, time.February, 29}},
+			{Date{2025, time.March, 31}, -1, Date{2025, time.February, 28}},
+			{Date{2025, time.November, 30}, 3, Date{2026, time.February, 28}},
+			{Date{2025, time.May, 15}, -17, Date{2023, time.December, 15}},
+		}
+		for _, test := range tests {
+			assert.Equal(t, test.exp, test.from.AddMonths(test.months), "%s + %d months", test.from, test.months)
+		}
+		assert.Equal(t, Date{2025, time.February, 28}, Date{2024, time.February, 29}.AddYears(1))
+	})
+	t.Run("DaysBetween", func(t *testing.T) {
+		tests := []struct {
+			a, b Date
+			exp  int
+		}{
+			{MustParse("2025-01-01"), MustParse("2025-01-01"), 0},
+			{MustParse("2025-01-01"), MustParse("2025-03-01"), 59},
+			{MustParse("2024-01-01"), MustParse("2024-03-01"), 60},
+			{MustParse("2025-03-01"), MustParse("2025-01-01"), -59},
+			{MustParse("1600-01-01"), MustParse("2000-01-01"), 146097},
+		}
+		for _, test := range tests {
+			assert.Equal(t, test.exp, DaysBetween(test.a, test.b), "%s to %s", test.a, test.b)
+			assert.Equal(t, test.exp, test.a.DaysUntil(test.b))
+			assert.Equal(t, test.exp, test.b.DaysSince(test.a))
+			assert.Equal(t, test.b, test.a.AddDays(test.exp))
+		}
+	})
+	t.Run("Calendar", func(t *testing.T) {
+		d := MustParse("2024-12-30")
+		assert.Equal(t, time.Monday, d.Weekday())
+		isoYear, week := d.ISOWeek()
+		assert.Equal(t, []int{2025, 1}, []int{isoYear, week})
+		assert.Equal(t, 365, d.YearDay())
+		assert.True(t, d.Before(d.AddDays(1)))
+		assert.True(t, d.After(d.AddDays(-1)))
+		assert.Equal(t, 0, d.Compare(New(2024, time.December, 30)))
+	})
+	t.Run("Leap years", func(t *testing.T) {
+		for yr, exp := range map[year]bool{2024: true, 2025: false, 1900: false, 2000: true, 2400: true} {
+			assert.Equal(t, exp, yr.isLeapYear(), "%d", yr)
+		}
+		assert.Equal(t, 366, DaysInYear(2000))
+	})
+	t.Run("Parse", func(t *testing.T) {
+		d, err := Parse("2025-01-03")
+		assert.NoError(t, err)
+		assert.Equal(t, Date{2025, time.January, 3}, d)
+		assert.Equal(t, "2025-01-03", d.String())
+
+		for _, bad := range []string{"2025-1-3", "2025-02-30", "03/01/2025", ""} {
+			_, err = Parse(bad)
+			assert.Error(t, err, bad)
+		}
+	})
+	t.Run("JSON", func(t *testing.T) {
+		type payload struct {
+			On Date `json:"on"`
+		}
+		out, err := json.Marshal(payload{On: MustParse("2025-01-03")})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"on":"2025-01-03"}`, string(out))
+
+		var decoded payload
+		assert.NoError(t, json.Unmarshal(out, &decoded))
+		assert.Equal(t, MustParse("2025-01-03"), decoded.On)
+		assert.Error(t, json.Unmarshal([]byte(`{"on":"yesterday"}`), &decoded))
+
+		out, err = json.Marshal(payload{})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"on":""}`, string(out), "the zero Date")
+		decoded = payload{On: MustParse("2025-01-03")}
+		assert.NoError(t, json.Unmarshal(out, &decoded))
+		assert.True(t, decoded.On.IsZero(), "round trips")
+	})
+	t.Run("SQL", func(t *testing.T) {
+		d := MustParse("2025-01-03")
+		value, err := d.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2025, time.January, 3, 0, 0, 0, 0, time.UTC), value)
+
+		for _, src := range []any{value, "2025-01-03", []byte("2025-01-03")} {
+			var scanned Date
+			assert.NoError(t, scanned.Scan(src))
+			assert.Equal(t, d, scanned)
+		}
+		var scanned Date
+		assert.NoError(t, scanned.Scan(nil))
+		assert.True(t, scanned.IsZero())
+		value, err = scanned.Value()
+		assert.NoError(t, err)
+		assert.Nil(t, value, "NULL is written back as NULL")
+		assert.Error(t, scanned.Scan(42))
+	})
+}
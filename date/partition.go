@@ -0,0 +1,119 @@
+package date
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The placeholders a PartitionTemplate replaces with a date's zero padded year, month and day
+const (
+	PlaceholderYear  = "{yyyy}"
+	PlaceholderMonth = "{mm}"
+	PlaceholderDay   = "{dd}"
+)
+
+var ErrKeyNotPartitioned = errors.New("key does not match the partition template")
+
+// PartitionTemplate renders and parses date partitioned keys such as prefix/yyyy=2025/mm=01/dd=03/
+type PartitionTemplate struct {
+	pattern string
+}
+
+// NewPartitionTemplate accepts a pattern like "prefix/yyyy={yyyy}/mm={mm}/dd={dd}/" holding each placeholder once
+func NewPartitionTemplate(pattern string) (PartitionTemplate, error) {
+	for _, placeholder := range []string{PlaceholderYear, PlaceholderMonth, PlaceholderDay} {
+		if count := strings.Count(pattern, placeholder); count != 1 {
+			return PartitionTemplate{}, fmt.Errorf("partition pattern %q has %s %d times, it must appear once", pattern, placeholder, count)
+		}
+	}
+	return PartitionTemplate{pattern: pattern}, nil
+}
+
+// HivePartitions is the template for prefix/yyyy=2025/mm=01/dd=03/, or yyyy=2025/mm=01/dd=03/ for an empty prefix
+func HivePartitions(prefix string) PartitionTemplate {
+	pattern := "yyyy={yyyy}/mm={mm}/dd={dd}/"
+	if prefix = strings.TrimSuffix(prefix, "/"); prefix != "" {
+		pattern = prefix + "/" + pattern
+	}
+	return PartitionTemplate{pattern: pattern}
+}
+
+func (template PartitionTemplate) String() string {
+	return template.pattern
+}
+
+// Render returns the partition key of d
+func (template PartitionTemplate) Render(d Date) string {
+	return strings.NewReplacer(
+		PlaceholderYear, fmt.Sprintf("%04d", d.Year),
+		PlaceholderMonth, fmt.Sprintf("%02d", d.Month),
+		PlaceholderDay, fmt.Sprintf("%02d", d.Day),
+	).Replace(template.pattern)
+}
+
+// RenderRange returns the partition key of every day of r, in order
+func (template PartitionTemplate) RenderRange(r DateRange) []string {
+	keys := make([]string, 0, r.Days())
+	for d := range r.All() {
+		keys = append(keys, template.Render(d))
+	}
+	return keys
+}
+
+// Parse returns the date of a key rendered by the template, which may be followed by more of the key,
+// e.g. the object name. It wraps ErrKeyNotPartitioned if the key does not match.
+func (template PartitionTemplate) Parse(key string) (Date, error) {
+	values := map[string]int{}
+	rest, pattern := key, template.pattern
+	for len(pattern) > 0 {
+		start := strings.IndexByte(pattern, '{')
+		if start < 0 {
+			start = len(pattern)
+		}
+		literal := pattern[:start]
+		if !strings.HasPrefix(rest, literal) {
+			return Date{}, fmt.Errorf("%w: %q does not match %q", ErrKeyNotPartitioned, key, template.pattern)
+		}
+		rest, pattern = rest[len(literal):], pattern[start:]
+		if len(pattern) == 0 {
+			break
+		}
+
+		placeholder, digits := pattern, 0
+		switch {
+		case strings.HasPrefix(pattern, PlaceholderYear):
+			placeholder, digits = PlaceholderYear, 4
+		case strings.HasPrefix(pattern, PlaceholderMonth):
+			placeholder, digits = PlaceholderMonth, 2
+		case strings.HasPrefix(pattern, PlaceholderDay):
+			placeholder, digits = PlaceholderDay, 2
+		default: // a literal brace
+			placeholder = "{"
+		}
+		if digits == 0 {
+			if !strings.HasPrefix(rest, placeholder) {
+				return Date{}, fmt.Errorf("%w: %q does not match %q", ErrKeyNotPartitioned, key, template.pattern)
+			}
+			rest, pattern = rest[1:], pattern[1:]
+			continue
+		}
+		if len(rest) < digits {
+			return Date{}, fmt.Errorf("%w: %q is too short for %q", ErrKeyNotPartitioned, key, template.pattern)
+		}
+		value, err := strconv.Atoi(rest[:digits])
+		if err != nil || strings.ContainsAny(rest[:digits], "+-") {
+			return Date{}, fmt.Errorf("%w: %q has %q in place of %s", ErrKeyNotPartitioned, key, rest[:digits], placeholder)
+		}
+		values[placeholder] = value
+		rest, pattern = rest[digits:], pattern[len(placeholder):]
+	}
+
+	d := Date{Year: values[PlaceholderYear], Month: time.Month(values[PlaceholderMonth]), Day: values[PlaceholderDay]}
+	if New(d.Year, d.Month, d.Day) != d {
+		return Date{}, fmt.Errorf("%w: %q holds the invalid date %s", ErrKeyNotPartitioned, key, d)
+	}
+	return d, nil
+}
@@ -0,0 +1,62 @@
+package date
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionTemplate(t *testing.T) {
+	hive := HivePartitions("data/events/")
+
+	t.Run("Render", func(t *testing.T) {
+		assert.Equal(t, "data/events/yyyy=2025/mm=01/dd=03/", hive.Render(MustParse("2025-01-03")))
+		assert.Equal(t, []string{
+			"data/events/yyyy=2024/mm=12/dd=31/",
+			"data/events/yyyy=2025/mm=01/dd=01/",
+		}, hive.RenderRange(rangeOf("2024-12-31", "2025-01-01")))
+		assert.Equal(t, "yyyy=2025/mm=01/dd=03/", HivePartitions("").Render(MustParse("2025-01-03")), "no leading slash")
+		assert.Equal(t, HivePartitions("data"), HivePartitions("data/"))
+	})
+	t.Run("Parse", func(t *testing.T) {
+		tests := []struct {
+			key string
+			exp Date
+			ok  bool
+		}{
+			{"data/events/yyyy=2025/mm=01/dd=03/", MustParse("2025-01-03"), true},
+			{"data/events/yyyy=2025/mm=01/dd=03/part-0000.avro", MustParse("2025-01-03"), true},
+			{"data/other/yyyy=2025/mm=01/dd=03/", Date{}, false},
+			{"data/events/yyyy=2025/mm=1/dd=03/", Date{}, false},
+			{"data/events/yyyy=2025/mm=02/dd=30/", Date{}, false},
+			{"data/events/yyyy=2025/mm=+1/dd=03/", Date{}, false},
+			{"data/events/yyyy=2025/mm=01/dd=03", Date{}, false},
+			{"data/events/yyyy=20", Date{}, false},
+		}
+		for _, test := range tests {
+			d, err := hive.Parse(test.key)
+			if test.ok {
+				assert.NoError(t, err, test.key)
+			} else {
+				assert.ErrorIs(t, err, ErrKeyNotPartitioned, test.key)
+			}
+			assert.Equal(t, test.exp, d, test.key)
+		}
+	})
+	t.Run("custom patterns", func(t *testing.T) {
+		template, err := NewPartitionTemplate("{dd}-{mm}-{yyyy}/{x}/")
+		require.NoError(t, err)
+		d := MustParse("2025-07-04")
+		key := template.Render(d)
+		assert.Equal(t, "04-07-2025/{x}/", key)
+		parsed, err := template.Parse(key + "file")
+		assert.NoError(t, err)
+		assert.Equal(t, d, parsed)
+
+		_, err = NewPartitionTemplate("prefix/{yyyy}/{mm}/")
+		assert.Error(t, err, "every placeholder is required")
+		_, err = NewPartitionTemplate("{yyyy}/{mm}/{dd}/{dd}")
+		assert.Error(t, err, "placeholders may only appear once")
+	})
+}
@@ -0,0 +1,94 @@
+package date
+
+import (
+	"iter"
+	"time"
+)
+
+// DateRange is every day from Start to End, including both. It is empty if End is before Start
+type DateRange struct {
+	Start Date
+	End   Date
+}
+
+// NewDateRange returns the range from start to end, swapping them if end is before start
+func NewDateRange(start, end Date) DateRange {
+	if end.Before(start) {
+		start, end = end, start
+	}
+	return DateRange{Start: start, End: end}
+}
+
+func (r DateRange) String() string {
+	return r.Start.String() + "/" + r.End.String()
+}
+
+func (r DateRange) IsEmpty() bool {
+	return r.End.Before(r.Start)
+}
+
+// Days is the number of days in the range
+func (r DateRange) Days() int {
+	return max(DaysBetween(r.Start, r.End)+1, 0)
+}
+
+func (r DateRange) Contains(d Date) bool {
+	return !d.Before(r.Start) && !d.After(r.End)
+}
+
+// Overlaps reports whether both ranges have at least one day in common
+func (r DateRange) Overlaps(other DateRange) bool {
+	return !r.Intersect(other).IsEmpty()
+}
+
+// Intersect is the days in both ranges, which may be empty
+func (r DateRange) Intersect(other DateRange) DateRange {
+	start, end := r.Start, r.End
+	if other.Start.After(start) {
+		start = other.Start
+	}
+	if other.End.Before(end) {
+		end = other.End
+	}
+	return DateRange{Start: start, End: end}
+}
+
+// All iterates the days of the range in order
+func (r DateRange) All() iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for d := r.Start; !d.After(r.End); d = d.AddDays(1) {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// SplitByMonth splits the range at the start of each calendar month, so only the first and last parts may be partial
+func (r DateRange) SplitByMonth() []DateRange {
+	return r.split(func(d Date) Date {
+		return New(d.Year, d.Month+1, 1)
+	})
+}
+
+// SplitByWeek splits the range at every weekStart day, so only the first and last parts may be partial
+func (r DateRange) SplitByWeek(weekStart time.Weekday) []DateRange {
+	return r.split(func(d Date) Date {
+		return d.AddDays((int(weekStart)-int(d.Weekday())+6)%7 + 1)
+	})
+}
+
+// split cuts the range before every day returned by next, which must be after the day it is given
+func (r DateRange) split(next func(Date) Date) []DateRange {
+	var parts []DateRange
+	for start := r.Start; !start.After(r.End); {
+		nextStart := next(start)
+		end := nextStart.AddDays(-1)
+		if end.After(r.End) {
+			end = r.End
+		}
+		parts = append(parts, DateRange{Start: start, End: end})
+		start = nextStart
+	}
+	return parts
+}
@@ -0,0 +1,75 @@
+package date
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rangeOf(start, end string) DateRange {
+	return DateRange{Start: MustParse(start), End: MustParse(end)}
+}
+
+func TestDateRange(t *testing.T) {
+	january := rangeOf("2025-01-01", "2025-01-31")
+
+	t.Run("Days", func(t *testing.T) {
+		assert.Equal(t, 31, january.Days())
+		assert.Equal(t, 1, rangeOf("2025-01-01", "2025-01-01").Days())
+		assert.Equal(t, 0, rangeOf("2025-01-02", "2025-01-01").Days())
+		assert.True(t, rangeOf("2025-01-02", "2025-01-01").IsEmpty())
+		assert.Equal(t, rangeOf("2025-01-01", "2025-01-02"), NewDateRange(MustParse("2025-01-02"), MustParse("2025-01-01")))
+	})
+	t.Run("Contains", func(t *testing.T) {
+		tests := map[string]bool{"2024-12-31": false, "2025-01-01": true, "2025-01-15": true, "2025-01-31": true, "2025-02-01": false}
+		for d, exp := range tests {
+			assert.Equal(t, exp, january.Contains(MustParse(d)), d)
+		}
+	})
+	t.Run("Overlaps", func(t *testing.T) {
+		tests := []struct {
+			other DateRange
+			exp   bool
+		}{
+			{rangeOf("2024-12-01", "2024-12-31"), false},
+			{rangeOf("2024-12-01", "2025-01-01"), true},
+			{rangeOf("2025-01-10", "2025-01-12"), true},
+			{rangeOf("2025-01-31", "2025-03-01"), true},
+			{rangeOf("2025-02-01", "2025-03-01"), false},
+		}
+		for _, test := range tests {
+			assert.Equal(t, test.exp, january.Overlaps(test.other), test.other.String())
+			assert.Equal(t, test.exp, test.other.Overlaps(january), test.other.String())
+		}
+		assert.Equal(t, rangeOf("2025-01-31", "2025-01-31"), january.Intersect(rangeOf("2025-01-31", "2025-03-01")))
+	})
+	t.Run("All", func(t *testing.T) {
+		days := slices.Collect(rangeOf("2024-02-27", "2024-03-01").All())
+		assert.Equal(t, []Date{MustParse("2024-02-27"), MustParse("2024-02-28"), MustParse("2024-02-29"), MustParse("2024-03-01")}, days)
+		assert.Empty(t, slices.Collect(rangeOf("2025-01-02", "2025-01-01").All()))
+		assert.Equal(t, january.Days(), len(slices.Collect(january.All())))
+	})
+	t.Run("SplitByMonth", func(t *testing.T) {
+		assert.Equal(t, []DateRange{
+			rangeOf("2024-12-15", "2024-12-31"),
+			rangeOf("2025-01-01", "2025-01-31"),
+			rangeOf("2025-02-01", "2025-02-03"),
+		}, rangeOf("2024-12-15", "2025-02-03").SplitByMonth())
+		assert.Equal(t, []DateRange{january}, january.SplitByMonth())
+		assert.Empty(t, rangeOf("2025-01-02", "2025-01-01").SplitByMonth())
+	})
+	t.Run("SplitByWeek", func(t *testing.T) {
+		// 2025-01-01 is a Wednesday
+		assert.Equal(t, []DateRange{
+			rangeOf("2025-01-01", "2025-01-05"),
+			rangeOf("2025-01-06", "2025-01-12"),
+			rangeOf("2025-01-13", "2025-01-14"),
+		}, rangeOf("2025-01-01", "2025-01-14").SplitByWeek(time.Monday))
+		assert.Equal(t, []DateRange{
+			rangeOf("2025-01-01", "2025-01-01"),
+			rangeOf("2025-01-02", "2025-01-08"),
+		}, rangeOf("2025-01-01", "2025-01-08").SplitByWeek(time.Thursday))
+	})
+}
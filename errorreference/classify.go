@@ -0,0 +1,101 @@
+package errorreference
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// ErrorClass describes how a caller should react to an error, mainly whether retrying it can help
+type ErrorClass int
+
+const (
+	ClassPermanent ErrorClass = iota // retrying will not help
+	ClassThrottled                   // the dependency asked us to slow down, retry after backing off
+	ClassTransient                   // a temporary failure, retrying may succeed
+	ClassCancelled                   // the caller gave up, do not retry
+)
+
+func (class ErrorClass) String() string {
+	switch class {
+	case ClassPermanent:
+		return "permanent"
+	case ClassThrottled:
+		return "throttled"
+	case ClassTransient:
+		return "transient"
+	case ClassCancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrorTransient marks a failure that may succeed when retried, such as a dropped connection or a 5xx
+var ErrorTransient = errors.New("transient failure")
+
+type classifiedError struct {
+	sentinel error
+	class    ErrorClass
+}
+
+var (
+	classMut = &sync.RWMutex{}
+	// registered sentinels are checked in order, so later registrations are prepended to override defaults
+	errorClasses = []*classifiedError{
+		{context.Canceled, ClassCancelled},
+		{context.DeadlineExceeded, ClassCancelled},
+		{ErrorSlowDown, ClassThrottled},
+		{ErrorTransient, ClassTransient},
+		{ErrorFailedToSend, ClassTransient},
+		{ErrorNotFound, ClassPermanent},
+		{ErrInvalidRequest, ClassPermanent},
+		{ErrorCircuitOpen, ClassPermanent}, // retrying would only hit the open breaker again
+	}
+)
+
+// RegisterErrorClass makes Classify return class for any error matching sentinel with errors.Is.
+// Registrations take priority over the defaults and over earlier registrations.
+// The returned func removes the registration again, mainly for tests.
+func RegisterErrorClass(sentinel error, class ErrorClass) (unregister func()) {
+	registered := &classifiedError{sentinel, class}
+	classMut.Lock()
+	defer classMut.Unlock()
+	errorClasses = append([]*classifiedError{registered}, errorClasses...)
+	return func() {
+		classMut.Lock()
+		defer classMut.Unlock()
+		errorClasses = slices.DeleteFunc(errorClasses, func(classified *classifiedError) bool {
+			return classified == registered
+		})
+	}
+}
+
+// Classify returns the class of the first registered sentinel in err's chain.
+// Unregistered errors that report Timeout() or Temporary() are transient, anything else is permanent.
+func Classify(err error) ErrorClass {
+	classMut.RLock()
+	defer classMut.RUnlock()
+	for _, classified := range errorClasses {
+		if errors.Is(err, classified.sentinel) {
+			return classified.class
+		}
+	}
+
+	var timeout interface{ Timeout() bool }
+	if errors.As(err, &timeout) && timeout.Timeout() {
+		return ClassTransient
+	}
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) && temporary.Temporary() {
+		return ClassTransient
+	}
+	return ClassPermanent
+}
+
+// IsRetryable is true for throttled and transient errors. It can be used directly as a utils.RetryPolicy's Retryable
+func IsRetryable(err error) bool {
+	class := Classify(err)
+	return class == ClassThrottled || class == ClassTransient
+}
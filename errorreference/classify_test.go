@@ -0,0 +1,55 @@
+package errorreference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "timed out" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return false }
+
+func TestClassify(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		assert.Equal(t, ClassCancelled, Classify(context.Canceled))
+		assert.Equal(t, ClassCancelled, Classify(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)))
+		assert.Equal(t, ClassThrottled, Classify(ErrorSlowDown))
+		assert.Equal(t, ClassTransient, Classify(fmt.Errorf("%w: connection reset", ErrorTransient)))
+		assert.Equal(t, ClassTransient, Classify(timeoutErr{}))
+		assert.Equal(t, ClassPermanent, Classify(ErrorNotFound))
+		assert.Equal(t, ClassPermanent, Classify(errors.New("unknown")))
+	})
+	t.Run("joined errors use the first registered match", func(t *testing.T) {
+		assert.Equal(t, ClassCancelled, Classify(errors.Join(ErrorSlowDown, context.Canceled)))
+	})
+	t.Run("IsRetryable", func(t *testing.T) {
+		assert.True(t, IsRetryable(ErrorSlowDown))
+		assert.True(t, IsRetryable(ErrorTransient))
+		assert.False(t, IsRetryable(ErrorNotFound))
+		assert.False(t, IsRetryable(context.Canceled))
+	})
+	t.Run("registered sentinels override defaults", func(t *testing.T) {
+		custom := errors.New("custom")
+		assert.Equal(t, ClassPermanent, Classify(custom))
+		t.Cleanup(RegisterErrorClass(custom, ClassThrottled))
+		assert.Equal(t, ClassThrottled, Classify(fmt.Errorf("wrapped: %w", custom)))
+
+		notFoundIsTransient := fmt.Errorf("%w: eventually consistent", ErrorNotFound)
+		t.Cleanup(RegisterErrorClass(notFoundIsTransient, ClassTransient))
+		assert.Equal(t, ClassTransient, Classify(notFoundIsTransient))
+		assert.Equal(t, ClassPermanent, Classify(ErrorNotFound))
+	})
+	t.Run("registrations can be removed", func(t *testing.T) {
+		custom := errors.New("custom")
+		unregister := RegisterErrorClass(custom, ClassTransient)
+		assert.Equal(t, ClassTransient, Classify(custom))
+		unregister()
+		assert.Equal(t, ClassPermanent, Classify(custom))
+		unregister() // removing twice is harmless
+	})
+}
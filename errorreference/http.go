@@ -1,8 +1,12 @@
 package errorreference
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"reflect"
+	"slices"
+	"sync"
 )
 
 // errors related to http-based process activity
@@ -13,43 +17,170 @@ var (
 	ErrInvalidRequest = errors.New("invalid request")
 	ErrCuda700        = errors.New("got 700 from cuda invocation, will kill task")
 	PanicDuringGoFunc = errors.New("paniced during a go func") // 400
+	ErrorCircuitOpen  = errors.New("circuit breaker open")     // 503
 )
 
-var knownErrors = map[error]int{
-	ErrorNotFound:     http.StatusNotFound,
-	ErrorSlowDown:     http.StatusTooManyRequests,
-	ErrorFailedToSend: http.StatusInternalServerError,
-	ErrInvalidRequest: http.StatusBadRequest,
-	//ErrCuda700: 500// TODO: ?
+type knownError struct {
+	sentinel error
+	code     int
 }
 
-// TODO: special error types?
-type HttpError interface {
-	error
-	statusCode() int // will return -1 for unknown
+var (
+	knownErrorsMut = &sync.RWMutex{}
+	// checked in order with errors.Is, so later registrations are prepended to override defaults
+	knownErrors = []*knownError{
+		{ErrorNotFound, http.StatusNotFound},
+		{ErrorSlowDown, http.StatusTooManyRequests},
+		{ErrorFailedToSend, http.StatusInternalServerError},
+		{ErrInvalidRequest, http.StatusBadRequest},
+		{ErrorCircuitOpen, http.StatusServiceUnavailable},
+		{context.DeadlineExceeded, http.StatusGatewayTimeout},
+		//ErrCuda700: 500// TODO: ?
+	}
+)
+
+// RegisterStatusCode makes StatusCodeOf return code for any error matching sentinel with errors.Is.
+// Registrations take priority over the defaults and over earlier registrations.
+// The returned func removes the registration again, mainly for tests.
+func RegisterStatusCode(sentinel error, code int) (unregister func()) {
+	registered := &knownError{sentinel, code}
+	knownErrorsMut.Lock()
+	defer knownErrorsMut.Unlock()
+	knownErrors = append([]*knownError{registered}, knownErrors...)
+	return func() {
+		knownErrorsMut.Lock()
+		defer knownErrorsMut.Unlock()
+		knownErrors = slices.DeleteFunc(knownErrors, func(known *knownError) bool {
+			return known == registered
+		})
+	}
+}
+
+// StatusCoder is implemented by errors that know their http status code, such as *HttpError
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// StatusCodeOf resolves the http status code for err.
+// The first StatusCoder in err's chain wins, then the first registered sentinel err matches.
+// nil is http.StatusOK, and any other error is http.StatusInternalServerError.
+func StatusCodeOf(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	if code := codeInChain(err); code != 0 {
+		return code
+	}
+	knownErrorsMut.RLock()
+	defer knownErrorsMut.RUnlock()
+	for _, known := range knownErrors {
+		if errors.Is(err, known.sentinel) {
+			return known.code
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// codeInChain returns the first non-zero code from a StatusCoder in err's chain, in errors.As order
+func codeInChain(err error) int {
+	if err == nil || isNilPointer(err) {
+		return 0 // a typed nil returned as an error
+	}
+	if coder, ok := err.(StatusCoder); ok && coder.StatusCode() != 0 {
+		return coder.StatusCode()
+	}
+	switch unwrappable := err.(type) {
+	case interface{ Unwrap() error }:
+		if inner := unwrappable.Unwrap(); inner != nil {
+			return codeInChain(inner)
+		}
+	case interface{ Unwrap() []error }:
+		for _, inner := range unwrappable.Unwrap() {
+			if code := codeInChain(inner); code != 0 {
+				return code
+			}
+		}
+	}
+	return 0
 }
 
-type httpErrorImpl struct {
-	err  error
-	code int
+func isNilPointer(err error) bool {
+	value := reflect.ValueOf(err)
+	return value.Kind() == reflect.Pointer && value.IsNil()
 }
 
-func (e httpErrorImpl) Error() string {
-	return e.err.Error()
+// HttpError carries an http status code alongside an error.
+// Message is safe to show to clients, while Err is the internal cause and is only exposed through Error() and Unwrap().
+// A nil *HttpError behaves as an empty one, so a typed nil returned as an error reads as a 500.
+type HttpError struct {
+	Code    int
+	Message string
+	Err     error
 }
 
-func (e httpErrorImpl) statusCode() int {
-	return e.code
+// NewHttpError builds an HttpError. message and cause are both optional
+func NewHttpError(code int, message string, cause error) *HttpError {
+	return &HttpError{Code: code, Message: message, Err: cause}
 }
 
-func WrapError(err error) httpErrorImpl { // TODO: ok? test?
-	code := -1
-	foundCode, exists := knownErrors[err]
-	if exists {
-		code = foundCode
+func (e *HttpError) Error() string {
+	switch {
+	case e == nil:
+		return http.StatusText(http.StatusInternalServerError)
+	case e.Err != nil && e.Message != "":
+		return e.Message + ": " + e.Err.Error()
+	case e.Err != nil:
+		return e.Err.Error()
+	default:
+		return e.PublicMessage()
+	}
+}
+
+func (e *HttpError) StatusCode() int {
+	if e == nil {
+		return 0
+	}
+	return e.Code
+}
+
+// PublicMessage is the client-facing message.
+// Without a Message, it falls back to the message of an *HttpError in the cause, then to the status text for the code,
+// or for http.StatusInternalServerError if the code is not a known status
+func (e *HttpError) PublicMessage() string {
+	if e == nil {
+		return http.StatusText(http.StatusInternalServerError)
+	}
+	if e.Message != "" {
+		return e.Message
+	}
+	var inner *HttpError
+	if errors.As(e.Err, &inner) {
+		return inner.PublicMessage()
+	}
+	if text := http.StatusText(e.Code); text != "" {
+		return text
+	}
+	return http.StatusText(http.StatusInternalServerError)
+}
+
+func (e *HttpError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// WrapError returns err as an *HttpError, resolving its status code with StatusCodeOf. nil stays nil, and an *HttpError
+// with a code is returned as is
+func WrapError(err error) *HttpError {
+	if err == nil {
+		return nil
+	}
+	if httpErr, ok := err.(*HttpError); ok && httpErr != nil && httpErr.Code != 0 {
+		return httpErr
 	}
-	return httpErrorImpl{
-		err:  err,
-		code: code,
+	return &HttpError{
+		Err:  err,
+		Code: StatusCodeOf(err),
 	}
 }
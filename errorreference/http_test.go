@@ -0,0 +1,82 @@
+package errorreference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+type conflictError struct{}
+
+func (conflictError) Error() string   { return "version conflict" }
+func (conflictError) StatusCode() int { return http.StatusConflict }
+
+func TestHttpError(t *testing.T) {
+	t.Run("StatusCodeOf", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, StatusCodeOf(nil))
+		assert.Equal(t, http.StatusNotFound, StatusCodeOf(ErrorNotFound))
+		assert.Equal(t, http.StatusNotFound, StatusCodeOf(fmt.Errorf("loading user: %w", ErrorNotFound)))
+		assert.Equal(t, http.StatusServiceUnavailable, StatusCodeOf(errors.Join(errors.New("other"), ErrorCircuitOpen)))
+		assert.Equal(t, http.StatusGatewayTimeout, StatusCodeOf(context.DeadlineExceeded))
+		assert.Equal(t, http.StatusInternalServerError, StatusCodeOf(errors.New("unknown")))
+		assert.Equal(t, http.StatusTeapot, StatusCodeOf(fmt.Errorf("wrapped: %w", NewHttpError(http.StatusTeapot, "", ErrorNotFound))))
+		assert.Equal(t, http.StatusConflict, StatusCodeOf(fmt.Errorf("saving: %w", conflictError{})), "any StatusCoder is used")
+		assert.Equal(t, http.StatusNotFound, StatusCodeOf(fmt.Errorf("%w: %w", &HttpError{Err: ErrorNotFound}, ErrorNotFound)), "a zero code falls through")
+		assert.Equal(t, http.StatusConflict, StatusCodeOf(&HttpError{Err: conflictError{}}), "to later codes in the chain")
+	})
+
+	t.Run("typed nils", func(t *testing.T) {
+		var nilErr *HttpError
+		assert.Equal(t, http.StatusInternalServerError, StatusCodeOf(fmt.Errorf("x: %w", nilErr)))
+		assert.Equal(t, http.StatusNotFound, StatusCodeOf(errors.Join(nilErr, ErrorNotFound)))
+		assert.Equal(t, http.StatusInternalServerError, StatusCodeOf(nilErr))
+		assert.Equal(t, http.StatusText(http.StatusInternalServerError), nilErr.Error())
+		assert.Equal(t, http.StatusText(http.StatusInternalServerError), nilErr.PublicMessage())
+		assert.Equal(t, "x: Internal Server Error", WrapError(fmt.Errorf("x: %w", nilErr)).Error())
+		assert.Equal(t, http.StatusInternalServerError, WrapError(nilErr).StatusCode())
+	})
+
+	t.Run("RegisterStatusCode", func(t *testing.T) {
+		errConflict := errors.New("conflict")
+		assert.Equal(t, http.StatusInternalServerError, StatusCodeOf(errConflict))
+		t.Cleanup(RegisterStatusCode(errConflict, http.StatusConflict))
+		assert.Equal(t, http.StatusConflict, StatusCodeOf(fmt.Errorf("saving: %w", errConflict)))
+	})
+
+	t.Run("messages and unwrapping", func(t *testing.T) {
+		cause := errors.New("db connection refused")
+		err := NewHttpError(http.StatusServiceUnavailable, "try again later", cause)
+		assert.Equal(t, "try again later", err.PublicMessage())
+		assert.Equal(t, "try again later: db connection refused", err.Error())
+		assert.ErrorIs(t, err, cause)
+		assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode())
+
+		assert.Equal(t, http.StatusText(http.StatusNotFound), NewHttpError(http.StatusNotFound, "", nil).PublicMessage())
+		assert.Equal(t, http.StatusText(http.StatusNotFound), NewHttpError(http.StatusNotFound, "", nil).Error())
+		assert.Equal(t, http.StatusText(http.StatusInternalServerError), (&HttpError{}).Error())
+	})
+
+	t.Run("errors.As", func(t *testing.T) {
+		var httpErr *HttpError
+		assert.True(t, errors.As(fmt.Errorf("handler: %w", NewHttpError(http.StatusBadRequest, "bad id", nil)), &httpErr))
+		assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode())
+	})
+
+	t.Run("WrapError", func(t *testing.T) {
+		assert.Nil(t, WrapError(nil))
+
+		wrapped := WrapError(fmt.Errorf("reading: %w", ErrorSlowDown))
+		assert.Equal(t, http.StatusTooManyRequests, wrapped.StatusCode())
+		assert.ErrorIs(t, wrapped, ErrorSlowDown)
+
+		inner := NewHttpError(http.StatusBadRequest, "bad id", nil)
+		assert.Same(t, inner, WrapError(inner))
+		outer := WrapError(fmt.Errorf("handler: %w", inner))
+		assert.Equal(t, http.StatusBadRequest, outer.StatusCode())
+		assert.Equal(t, "bad id", outer.PublicMessage())
+		assert.Equal(t, "handler: bad id", outer.Error())
+	})
+}
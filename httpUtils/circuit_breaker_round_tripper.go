@@ -0,0 +1,46 @@
+package httpUtils
+
+import (
+	"net/http"
+
+	"github.com/reeceappling/goUtils/v2/circuitbreaker"
+)
+
+var _ http.RoundTripper = &BreakerRoundTripper{}
+
+// BreakerRoundTripper is an http.RoundTripper that fails fast with errorreference.ErrorCircuitOpen while its breaker is open.
+// Transport errors, 429s and 5xx responses count as failures.
+type BreakerRoundTripper struct {
+	next    http.RoundTripper
+	breaker *circuitbreaker.Breaker
+}
+
+// NewBreakerRoundTripper wraps next, or http.DefaultTransport if next is nil
+func NewBreakerRoundTripper(next http.RoundTripper, settings circuitbreaker.Settings) *BreakerRoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &BreakerRoundTripper{next: next, breaker: circuitbreaker.New(settings)}
+}
+
+func (rt *BreakerRoundTripper) Breaker() *circuitbreaker.Breaker {
+	return rt.breaker
+}
+
+// RoundTrip meets the interface of http.RoundTripper
+func (rt *BreakerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	report, err := rt.breaker.Allow(req.Context())
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close() // RoundTrip must always close the body
+		}
+		return nil, err
+	}
+	failed := true
+	defer func() { report(failed) }()
+
+	res, err := rt.next.RoundTrip(req)
+	failed = rt.breaker.IsFailure(err) ||
+		(err == nil && (res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError))
+	return res, err
+}
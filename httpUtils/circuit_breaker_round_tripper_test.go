@@ -0,0 +1,38 @@
+package httpUtils
+
+import (
+	"github.com/reeceappling/goUtils/v2/circuitbreaker"
+	"github.com/reeceappling/goUtils/v2/errorreference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBreakerRoundTripper(t *testing.T) {
+	status := http.StatusInternalServerError
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	rt := NewBreakerRoundTripper(nil, circuitbreaker.Settings{Name: "test", MinRequests: 2, Cooldown: time.Hour})
+	client := &http.Client{Transport: rt}
+
+	for range 2 {
+		res, err := client.Get(server.URL)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	}
+	assert.Equal(t, circuitbreaker.StateOpen, rt.Breaker().State())
+
+	status = http.StatusOK
+	_, err := client.Get(server.URL) //nolint:bodyclose
+	assert.ErrorIs(t, err, errorreference.ErrorCircuitOpen)
+	assert.Equal(t, 2, calls, "open breaker should not reach the server")
+}
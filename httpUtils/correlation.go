@@ -0,0 +1,133 @@
+package httpUtils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	context2 "github.com/reeceappling/goUtils/v2/utils/context"
+)
+
+const (
+	HeaderRequestId       = "X-Request-Id"
+	HeaderParentRequestId = "X-Parent-Request-Id"
+	HeaderTraceparent     = "traceparent" // W3C trace context, version-traceId-parentId-flags
+	HeaderClientKey       = "X-Client-Key"
+)
+
+// CorrelationIds tie together the logs of one request, and of every request made to handle it
+type CorrelationIds struct {
+	TraceId         string // shared by every service handling the original request, from traceparent
+	RequestId       string // this service's handling of the request
+	ParentRequestId string // the caller's request, if the caller sent it
+	traceFlags      string
+}
+
+type correlationIdsKey struct{}
+
+func WithCorrelationIds(parent context.Context, ids CorrelationIds) context.Context {
+	ctx := context.WithValue(parent, correlationIdsKey{}, ids)
+	if ids.ParentRequestId != "" {
+		ctx = context2.SetStringInContext(ctx, context2.ParentRequestId, ids.ParentRequestId)
+	}
+	return ctx
+}
+
+// GetCorrelationIds returns the ids stored by WithCorrelationIds, or empty ids
+func GetCorrelationIds(ctx context.Context) CorrelationIds {
+	ids, _ := ctx.Value(correlationIdsKey{}).(CorrelationIds)
+	return ids
+}
+
+// maxIdLength caps the request ids accepted from callers, which end up in every log entry and response
+const maxIdLength = 128
+
+// correlationIdsFrom reads the ids sent with an incoming request, generating a trace id if there is no valid
+// traceparent. The request ids are left empty if the caller did not send valid ones, see validId
+func correlationIdsFrom(r *http.Request) CorrelationIds {
+	ids := CorrelationIds{
+		RequestId:       validId(r.Header.Get(HeaderRequestId)),
+		ParentRequestId: validId(r.Header.Get(HeaderParentRequestId)),
+	}
+	ids.TraceId, ids.traceFlags = parseTraceparent(r.Header.Get(HeaderTraceparent))
+	if ids.TraceId == "" {
+		ids.TraceId, ids.traceFlags = randomHex(16), "01"
+	}
+	return ids
+}
+
+// parseTraceparent returns the trace id and flags of a version 00 traceparent, or empty strings if it is invalid
+func parseTraceparent(header string) (traceId, flags string) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) != 4 || parts[0] != "00" ||
+		!isHex(parts[1], 32) || !isHex(parts[2], 16) || !isHex(parts[3], 2) ||
+		strings.Trim(parts[1], "0") == "" || strings.Trim(parts[2], "0") == "" {
+		return "", ""
+	}
+	return parts[1], parts[3]
+}
+
+// validId returns id if it is at most maxIdLength letters, digits and -_.:, such as a uuid, or an empty string
+func validId(id string) string {
+	if len(id) > maxIdLength {
+		return ""
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("-_.:", c)) {
+			return ""
+		}
+	}
+	return id
+}
+
+func isHex(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	for _, c := range s {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func randomHex(bytes int) string {
+	b := make([]byte, bytes)
+	_, _ = rand.Read(b) // never returns an error
+	return hex.EncodeToString(b)
+}
+
+// CorrelationRoundTripper sends the CorrelationIds of each request's context downstream.
+// Every call gets a new request id, with the current request id as its parent, and a traceparent continuing the trace
+type CorrelationRoundTripper struct {
+	next http.RoundTripper
+}
+
+// NewCorrelationRoundTripper wraps next, or http.DefaultTransport if nil
+func NewCorrelationRoundTripper(next http.RoundTripper) *CorrelationRoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &CorrelationRoundTripper{next: next}
+}
+
+func (rt *CorrelationRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	ids := GetCorrelationIds(r.Context())
+	r = r.Clone(r.Context()) // a RoundTripper must not modify the request it is given
+	r.Header.Set(HeaderRequestId, uuid.New().String())
+	if ids.RequestId != "" {
+		r.Header.Set(HeaderParentRequestId, ids.RequestId)
+	}
+	if ids.TraceId != "" {
+		flags := ids.traceFlags
+		if flags == "" {
+			flags = "01"
+		}
+		r.Header.Set(HeaderTraceparent, "00-"+ids.TraceId+"-"+randomHex(8)+"-"+flags)
+	}
+	return rt.next.RoundTrip(r)
+}
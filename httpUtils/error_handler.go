@@ -0,0 +1,148 @@
+package httpUtils
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/reeceappling/goUtils/v2/errorreference"
+	"github.com/reeceappling/goUtils/v2/logging"
+	recover2 "github.com/reeceappling/goUtils/v2/recover"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// ErrorHandlerFunc is an http handler that returns its error instead of writing it
+type ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ErrorRenderer turns errors returned from an ErrorHandlerFunc into problem+json responses
+type ErrorRenderer struct {
+	// ShowInternalErrors puts the full error of 5xx responses in the detail.
+	// When false, 5xx details only contain an errorreference.HttpError's public message or the status text.
+	ShowInternalErrors bool
+	// ProblemType is the "type" URI of every problem, "about:blank" when empty
+	ProblemType string
+}
+
+// HandleErrors adapts f with an ErrorRenderer that hides internal errors
+func HandleErrors(f ErrorHandlerFunc) http.Handler {
+	return ErrorRenderer{}.Handle(f)
+}
+
+// Handle adapts f to an http.Handler.
+// A returned error has its status resolved through errorreference.StatusCodeOf, is logged through the request's logger
+// and is rendered as problem+json. Panics are logged and rendered as 500s, except http.ErrAbortHandler which is re-panicked
+// so the server can abort the response.
+func (renderer ErrorRenderer) Handle(f ErrorHandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder := newResponseRecorder(w)
+		defer func() {
+			recovered := recover()
+			if recovered == http.ErrAbortHandler { //nolint:errorlint // the server compares it the same way
+				panic(recovered)
+			}
+			if err := recover2.HandleRecoverAndLog(r.Context(), recovered); err != nil {
+				// already logged with its stack, so it is only written
+				renderer.write(recorder, r, errorreference.NewHttpError(http.StatusInternalServerError, "", err))
+			}
+		}()
+		if err := f(recorder, r); err != nil {
+			renderer.Render(recorder, r, err)
+		}
+	})
+}
+
+// Render logs err and writes it as problem+json, unless the response has already been started
+func (renderer ErrorRenderer) Render(w http.ResponseWriter, r *http.Request, err error) {
+	httpErr := errorreference.WrapError(err)
+	code := httpErr.StatusCode()
+
+	log := logging.GetSugaredLogger(r.Context())
+	logFields := []any{"error", err, logging.StatusCode, code, logging.RequestPath, r.URL.Path}
+	if code >= http.StatusInternalServerError {
+		log.Errorw("request failed", logFields...)
+	} else {
+		log.Warnw("request failed", logFields...)
+	}
+	renderer.write(w, r, err)
+}
+
+// write writes err as problem+json, unless the response has already been started
+func (renderer ErrorRenderer) write(w http.ResponseWriter, r *http.Request, err error) {
+	httpErr := errorreference.WrapError(err)
+	code := httpErr.StatusCode()
+	if recorder, ok := w.(*responseRecorder); ok && recorder.wroteHeader {
+		return // the handler already started its own response
+	}
+
+	problem := Problem{
+		Type:     renderer.ProblemType,
+		Title:    http.StatusText(code),
+		Status:   code,
+		Detail:   err.Error(),
+		Instance: r.URL.Path,
+	}
+	if problem.Type == "" {
+		problem.Type = "about:blank"
+	}
+	var publicErr *errorreference.HttpError
+	switch {
+	case code >= http.StatusInternalServerError && !renderer.ShowInternalErrors:
+		problem.Detail = httpErr.PublicMessage()
+	case code < http.StatusInternalServerError && errors.As(err, &publicErr):
+		problem.Detail = publicErr.PublicMessage() // an HttpError's cause is internal, even for client errors
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(code)
+	if encodeErr := json.NewEncoder(w).Encode(problem); encodeErr != nil {
+		logging.GetSugaredLogger(r.Context()).Errorw("failed to write problem response", "error", encodeErr)
+	}
+}
+
+// responseRecorder tracks what has been written through an http.ResponseWriter
+type responseRecorder struct {
+	http.ResponseWriter
+	wroteHeader bool
+	statusCode  int
+	bytes       int
+}
+
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	return &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+}
+
+func (w *responseRecorder) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.statusCode = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+// Flush sends what has been written so far, if the wrapped writer can, so streaming handlers work behind the recorder
+func (w *responseRecorder) Flush() {
+	w.wroteHeader = true // flushing sends the headers
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the wrapped writer
+func (w *responseRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,111 @@
+package httpUtils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/reeceappling/goUtils/v2/errorreference"
+	"github.com/reeceappling/goUtils/v2/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorRenderer(t *testing.T) {
+	serve := func(t *testing.T, handler http.Handler) (*httptest.ResponseRecorder, Problem) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/things/1", nil))
+		var problem Problem
+		if w.Header().Get("Content-Type") == ProblemContentType {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		}
+		return w, problem
+	}
+
+	t.Run("no error leaves the response alone", func(t *testing.T) {
+		w, _ := serve(t, HandleErrors(func(w http.ResponseWriter, r *http.Request) error {
+			_, _ = w.Write([]byte("ok"))
+			return nil
+		}))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "ok", w.Body.String())
+	})
+
+	t.Run("wrapped sentinels resolve their status", func(t *testing.T) {
+		w, problem := serve(t, HandleErrors(func(w http.ResponseWriter, r *http.Request) error {
+			return fmt.Errorf("loading thing: %w", errorreference.ErrorNotFound)
+		}))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, Problem{
+			Type:     "about:blank",
+			Title:    http.StatusText(http.StatusNotFound),
+			Status:   http.StatusNotFound,
+			Detail:   "loading thing: not found",
+			Instance: "/things/1",
+		}, problem)
+	})
+
+	t.Run("internal messages are hidden for 5xx", func(t *testing.T) {
+		failing := func(w http.ResponseWriter, r *http.Request) error {
+			return errors.New("password=hunter2")
+		}
+		w, problem := serve(t, HandleErrors(failing))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, http.StatusText(http.StatusInternalServerError), problem.Detail)
+
+		_, problem = serve(t, ErrorRenderer{ShowInternalErrors: true, ProblemType: "https://example.com/problem"}.Handle(failing))
+		assert.Equal(t, "password=hunter2", problem.Detail)
+		assert.Equal(t, "https://example.com/problem", problem.Type)
+	})
+
+	t.Run("causes of 4xx HttpErrors are hidden", func(t *testing.T) {
+		w, problem := serve(t, HandleErrors(func(w http.ResponseWriter, r *http.Request) error {
+			return fmt.Errorf("handler: %w", errorreference.NewHttpError(http.StatusBadRequest, "bad id", errors.New("db error")))
+		}))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "bad id", problem.Detail)
+	})
+
+	t.Run("public messages are shown for 5xx", func(t *testing.T) {
+		_, problem := serve(t, HandleErrors(func(w http.ResponseWriter, r *http.Request) error {
+			return errorreference.NewHttpError(http.StatusServiceUnavailable, "try again later", errors.New("db down"))
+		}))
+		assert.Equal(t, http.StatusServiceUnavailable, problem.Status)
+		assert.Equal(t, "try again later", problem.Detail)
+	})
+
+	t.Run("panics become 500s", func(t *testing.T) {
+		w, problem := serve(t, HandleErrors(func(w http.ResponseWriter, r *http.Request) error {
+			panic("boom")
+		}))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, http.StatusInternalServerError, problem.Status)
+
+		log := logging.NewTestLogger(t)
+		req := httptest.NewRequest(http.MethodGet, "/things/1", nil)
+		HandleErrors(func(w http.ResponseWriter, r *http.Request) error {
+			panic("boom")
+		}).ServeHTTP(httptest.NewRecorder(), req.WithContext(log.Install(req.Context())))
+		assert.Equal(t, 1, log.CountAtLevel(zapcore.ErrorLevel), "the panic is logged once: %s", log)
+	})
+
+	t.Run("aborted handlers are re-panicked", func(t *testing.T) {
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			serve(t, HandleErrors(func(w http.ResponseWriter, r *http.Request) error {
+				panic(http.ErrAbortHandler)
+			}))
+		})
+	})
+
+	t.Run("started responses are not overwritten", func(t *testing.T) {
+		w, _ := serve(t, HandleErrors(func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(http.StatusAccepted)
+			return errors.New("failed after responding")
+		}))
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+}
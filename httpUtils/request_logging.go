@@ -0,0 +1,57 @@
+package httpUtils
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/reeceappling/goUtils/v2/logging"
+	"go.uber.org/zap"
+)
+
+// RequestLogging stores CorrelationIds and a logger carrying them in each request's context, echoes the request id in
+// the X-Request-Id response header, and logs one access line per request with its status, bytes written and TaskTime.
+//
+// The request id is taken from X-Request-Id if the caller sent a valid one, otherwise it is generated. Ids longer than
+// 128 characters, or with characters other than letters, digits and -_.:, are not valid. The logger is forked
+// from the one already in the request's context, see logging.SetLogger.
+func RequestLogging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ids := correlationIdsFrom(r)
+
+		log := logging.GetLogger(r.Context())
+		if ids.RequestId != "" {
+			log = log.WithRequestId(r.Context(), ids.RequestId)
+		} else {
+			log, ids.RequestId = log.Fork(logging.RequestId)
+		}
+		log = log.WithTraceId(r.Context(), ids.TraceId)
+		if ids.ParentRequestId != "" {
+			log = log.WithStringKVP(logging.ParentRequestId, ids.ParentRequestId)
+		}
+		if clientKey := r.Header.Get(HeaderClientKey); clientKey != "" {
+			log = log.WithStringKVP(logging.ClientKey, clientKey)
+		}
+
+		ctx := logging.SetLogger(WithCorrelationIds(r.Context(), ids), log)
+		w.Header().Set(HeaderRequestId, ids.RequestId)
+		recorder := newResponseRecorder(w)
+
+		panicked := true
+		defer func() {
+			status := recorder.statusCode
+			if panicked {
+				status = http.StatusInternalServerError
+			}
+			log.Info("request completed",
+				zap.String("method", r.Method),
+				zap.String(logging.RequestPath, r.URL.Path),
+				zap.Int(logging.StatusCode, status),
+				zap.Int("bytes", recorder.bytes),
+				zap.Duration(logging.TaskTime, time.Since(start)),
+			)
+		}()
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+		panicked = false
+	})
+}
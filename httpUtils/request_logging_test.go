@@ -0,0 +1,197 @@
+package httpUtils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/reeceappling/goUtils/v2/logging"
+	context2 "github.com/reeceappling/goUtils/v2/utils/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// loggedRequest serves r through RequestLogging with a JSON logger, returning the response and every log entry
+func loggedRequest(t *testing.T, r *http.Request, handler http.HandlerFunc) (*httptest.ResponseRecorder, []map[string]any) {
+	t.Helper()
+	var buf bytes.Buffer
+	log := logging.NewLoggerFromConfig("test", logging.LoggerConfig{Sinks: []io.Writer{&buf}, Encoding: logging.EncodingJSON})
+	r = r.WithContext(logging.SetLogger(r.Context(), log))
+	w := httptest.NewRecorder()
+	func() {
+		defer func() { _ = recover() }()
+		RequestLogging(handler).ServeHTTP(w, r)
+	}()
+
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return w, entries
+}
+
+func TestRequestLogging(t *testing.T) {
+	t.Run("honours incoming ids", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/things", nil)
+		r.Header.Set(HeaderRequestId, "req-1")
+		r.Header.Set(HeaderParentRequestId, "parent-1")
+		r.Header.Set(HeaderTraceparent, traceparent)
+		r.Header.Set(HeaderClientKey, "client-1")
+
+		var ids CorrelationIds
+		w, entries := loggedRequest(t, r, func(w http.ResponseWriter, r *http.Request) {
+			ids = GetCorrelationIds(r.Context())
+			assert.Equal(t, "parent-1", context2.GetStringFromContext(r.Context(), context2.ParentRequestId))
+			logging.GetSugaredLogger(r.Context()).Info("inside")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte("created"))
+		})
+
+		assert.Equal(t, "req-1", w.Header().Get(HeaderRequestId))
+		assert.Equal(t, "req-1", ids.RequestId)
+		assert.Equal(t, "parent-1", ids.ParentRequestId)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", ids.TraceId)
+
+		require.Len(t, entries, 2)
+		for _, entry := range entries {
+			assert.Equal(t, "req-1", entry[logging.RequestId], "the handler's logs carry the ids too")
+			assert.Equal(t, ids.TraceId, entry[logging.TraceId])
+			assert.Equal(t, "parent-1", entry[logging.ParentRequestId])
+			assert.Equal(t, "client-1", entry[logging.ClientKey])
+		}
+		access := entries[1]
+		assert.Equal(t, "request completed", access["msg"])
+		assert.Equal(t, "POST", access["method"])
+		assert.Equal(t, "/things", access[logging.RequestPath])
+		assert.Equal(t, 201.0, access[logging.StatusCode])
+		assert.Equal(t, 7.0, access["bytes"])
+		assert.Contains(t, access, logging.TaskTime)
+	})
+
+	t.Run("generates missing ids", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(HeaderTraceparent, "00-00000000000000000000000000000000-00f067aa0ba902b7-01") // an invalid all-zero trace id
+		w, entries := loggedRequest(t, r, func(w http.ResponseWriter, r *http.Request) {})
+
+		requestId := w.Header().Get(HeaderRequestId)
+		assert.NotEmpty(t, requestId)
+		require.Len(t, entries, 1)
+		assert.Equal(t, requestId, entries[0][logging.RequestId])
+		assert.Len(t, entries[0][logging.TraceId], 32)
+		assert.NotEqual(t, "00000000000000000000000000000000", entries[0][logging.TraceId])
+		assert.NotContains(t, entries[0], logging.ParentRequestId)
+		assert.Equal(t, 200.0, entries[0][logging.StatusCode])
+	})
+
+	t.Run("replaces invalid ids", func(t *testing.T) {
+		for _, invalid := range []string{strings.Repeat("a", 129), "req 1", "req\"1", "req-1\u2028", "<script>"} {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set(HeaderRequestId, invalid)
+			r.Header.Set(HeaderParentRequestId, invalid)
+			w, entries := loggedRequest(t, r, func(w http.ResponseWriter, r *http.Request) {})
+
+			requestId := w.Header().Get(HeaderRequestId)
+			assert.NotEqual(t, invalid, requestId)
+			assert.NoError(t, uuid.Validate(requestId), "a new id is generated")
+			require.Len(t, entries, 1)
+			assert.NotContains(t, entries[0], logging.ParentRequestId)
+		}
+		assert.Equal(t, "a1b2-C3.d_4:5", validId("a1b2-C3.d_4:5"))
+		assert.Equal(t, strings.Repeat("a", 128), validId(strings.Repeat("a", 128)))
+	})
+
+	t.Run("streaming handlers can flush", func(t *testing.T) {
+		w, _ := loggedRequest(t, httptest.NewRequest(http.MethodGet, "/", nil), func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("event"))
+			flusher, ok := w.(http.Flusher)
+			require.True(t, ok)
+			flusher.Flush()
+		})
+		assert.True(t, w.Flushed)
+	})
+
+	t.Run("logs panics as 500s", func(t *testing.T) {
+		_, entries := loggedRequest(t, httptest.NewRequest(http.MethodGet, "/", nil), func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		})
+		require.Len(t, entries, 1)
+		assert.Equal(t, 500.0, entries[0][logging.StatusCode])
+	})
+}
+
+func TestParseTraceparent(t *testing.T) {
+	tests := map[string]string{
+		traceparent: "4bf92f3577b34da6a3ce929d0e0e4736",
+		"":          "",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01": "",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01": "",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01": "",
+		"00-4bf92f3577b34da6-00f067aa0ba902b7-01":                 "",
+	}
+	for header, exp := range tests {
+		traceId, _ := parseTraceparent(header)
+		assert.Equal(t, exp, traceId, header)
+	}
+}
+
+func TestCorrelationRoundTripper(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: NewCorrelationRoundTripper(nil)}
+
+	ctx := WithCorrelationIds(context.Background(), CorrelationIds{TraceId: "4bf92f3577b34da6a3ce929d0e0e4736", RequestId: "req-1"})
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	res, err := client.Do(r)
+	require.NoError(t, err)
+	_ = res.Body.Close()
+
+	assert.Empty(t, r.Header, "the caller's request is not modified")
+	assert.Equal(t, "req-1", received.Get(HeaderParentRequestId))
+	assert.NotEmpty(t, received.Get(HeaderRequestId))
+	assert.NotEqual(t, "req-1", received.Get(HeaderRequestId), "each call gets its own request id")
+	traceId, flags := parseTraceparent(received.Get(HeaderTraceparent))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceId)
+	assert.Equal(t, "01", flags)
+
+	t.Run("end to end", func(t *testing.T) { // a downstream service using RequestLogging continues the trace
+		var downstream CorrelationIds
+		handler := RequestLogging(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			downstream = GetCorrelationIds(r.Context())
+		}))
+		w := httptest.NewRecorder()
+		incoming := httptest.NewRequest(http.MethodGet, "/", nil)
+		incoming.Header = received
+		handler.ServeHTTP(w, incoming)
+		assert.Equal(t, CorrelationIds{
+			TraceId:         "4bf92f3577b34da6a3ce929d0e0e4736",
+			RequestId:       received.Get(HeaderRequestId),
+			ParentRequestId: "req-1",
+			traceFlags:      "01",
+		}, downstream)
+	})
+
+	t.Run("without ids", func(t *testing.T) {
+		r, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		res, err := client.Do(r)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		assert.NotEmpty(t, received.Get(HeaderRequestId))
+		assert.Empty(t, received.Get(HeaderParentRequestId))
+		assert.Empty(t, received.Get(HeaderTraceparent))
+	})
+}
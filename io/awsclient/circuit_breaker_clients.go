@@ -0,0 +1,192 @@
+package awsclient
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/redis/go-redis/v9"
+	"github.com/reeceappling/goUtils/v2/circuitbreaker"
+	"github.com/reeceappling/goUtils/v2/errorreference"
+	"github.com/reeceappling/goUtils/v2/logging"
+)
+
+// BreakerS3Client fails fast with errorreference.ErrorCircuitOpen while its breaker is open.
+// Unless settings.IsFailure is set, only throttled and transient errors count as failures,
+// so a missing key does not open the breaker.
+type BreakerS3Client struct {
+	client  S3Client
+	breaker *circuitbreaker.Breaker
+}
+
+var _ S3Client = BreakerS3Client{}
+
+func NewBreakerS3Client(client S3Client, settings circuitbreaker.Settings) BreakerS3Client {
+	if settings.IsFailure == nil {
+		settings.IsFailure = errorreference.IsRetryable
+	}
+	return BreakerS3Client{client: client, breaker: circuitbreaker.New(settings)}
+}
+
+func (adapter BreakerS3Client) Breaker() *circuitbreaker.Breaker {
+	return adapter.breaker
+}
+
+func (adapter BreakerS3Client) ListObjectsV2(ctx context.Context, input *s3.ListObjectsV2Input, options ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	return circuitbreaker.Execute(ctx, adapter.breaker, func(ctx context.Context) (*s3.ListObjectsV2Output, error) {
+		return adapter.client.ListObjectsV2(ctx, input, options...)
+	})
+}
+
+func (adapter BreakerS3Client) GetObject(ctx context.Context, input *s3.GetObjectInput, options ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	return circuitbreaker.Execute(ctx, adapter.breaker, func(ctx context.Context) (*s3.GetObjectOutput, error) {
+		return adapter.client.GetObject(ctx, input, options...)
+	})
+}
+
+func (adapter BreakerS3Client) PutObject(ctx context.Context, input *s3.PutObjectInput, options ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	return circuitbreaker.Execute(ctx, adapter.breaker, func(ctx context.Context) (*s3.PutObjectOutput, error) {
+		return adapter.client.PutObject(ctx, input, options...)
+	})
+}
+
+func (adapter BreakerS3Client) DeleteObject(ctx context.Context, input *s3.DeleteObjectInput, options ...func(*s3.Options)) (*s3.DeleteObjectOutput, error) {
+	return circuitbreaker.Execute(ctx, adapter.breaker, func(ctx context.Context) (*s3.DeleteObjectOutput, error) {
+		return adapter.client.DeleteObject(ctx, input, options...)
+	})
+}
+
+func (adapter BreakerS3Client) HeadObject(ctx context.Context, input *s3.HeadObjectInput, options ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	return circuitbreaker.Execute(ctx, adapter.breaker, func(ctx context.Context) (*s3.HeadObjectOutput, error) {
+		return adapter.client.HeadObject(ctx, input, options...)
+	})
+}
+
+// NewBreakerRedisClient wraps the client's connection so every command goes through a breaker.
+// While open, commands are not sent and carry errorreference.ErrorCircuitOpen as their error.
+// Unless settings.IsFailure is set, redis.Nil and cancellations do not count as failures.
+func NewBreakerRedisClient(client RedisClient, settings circuitbreaker.Settings) RedisClient {
+	if settings.IsFailure == nil {
+		settings.IsFailure = func(err error) bool {
+			return err != nil && !errors.Is(err, redis.Nil) && errorreference.Classify(err) != errorreference.ClassCancelled
+		}
+	}
+	return RedisClient{Client: breakerRedisClient{
+		client:  client.Client,
+		breaker: circuitbreaker.New(settings),
+	}}
+}
+
+type breakerRedisClient struct {
+	client  WrappedRedisClient
+	breaker *circuitbreaker.Breaker
+}
+
+var _ WrappedRedisClient = breakerRedisClient{}
+
+// redisWithBreaker runs call through the breaker, or returns the command built by whenOpen with the breaker's error
+func redisWithBreaker[C redis.Cmder](ctx context.Context, breaker *circuitbreaker.Breaker, call func() C, whenOpen func() C) C {
+	called := false
+	cmd, err := circuitbreaker.Execute(ctx, breaker, func(context.Context) (C, error) {
+		called = true
+		cmd := call()
+		return cmd, cmd.Err()
+	})
+	if !called {
+		cmd = whenOpen()
+		cmd.SetErr(err)
+	}
+	return cmd
+}
+
+func (wrapper breakerRedisClient) Get(ctx context.Context, key string) *redis.StringCmd {
+	return redisWithBreaker(ctx, wrapper.breaker,
+		func() *redis.StringCmd { return wrapper.client.Get(ctx, key) },
+		func() *redis.StringCmd { return redis.NewStringCmd(ctx, "get", key) },
+	)
+}
+
+func (wrapper breakerRedisClient) Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd {
+	return redisWithBreaker(ctx, wrapper.breaker,
+		func() *redis.StatusCmd { return wrapper.client.Set(ctx, key, value, expiration) },
+		func() *redis.StatusCmd { return redis.NewStatusCmd(ctx, "set", key, value) },
+	)
+}
+
+func (wrapper breakerRedisClient) SetNX(ctx context.Context, key string, value any, expiration time.Duration) *redis.BoolCmd {
+	return redisWithBreaker(ctx, wrapper.breaker,
+		func() *redis.BoolCmd { return wrapper.client.SetNX(ctx, key, value, expiration) },
+		func() *redis.BoolCmd { return redis.NewBoolCmd(ctx, "set", key, value, "nx") },
+	)
+}
+
+func (wrapper breakerRedisClient) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	return redisWithBreaker(ctx, wrapper.breaker,
+		func() *redis.IntCmd { return wrapper.client.Del(ctx, keys...) },
+		func() *redis.IntCmd { return redis.NewIntCmd(ctx, "del") },
+	)
+}
+
+func (wrapper breakerRedisClient) Do(ctx context.Context, args ...interface{}) *redis.Cmd {
+	return redisWithBreaker(ctx, wrapper.breaker,
+		func() *redis.Cmd { return wrapper.client.Do(ctx, args...) },
+		func() *redis.Cmd { return redis.NewCmd(ctx, args...) },
+	)
+}
+
+func (wrapper breakerRedisClient) Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+	return redisWithBreaker(ctx, wrapper.breaker,
+		func() *redis.ScanCmd { return wrapper.client.Scan(ctx, cursor, match, count) },
+		func() *redis.ScanCmd {
+			// Iterator calls process for more pages, so it must not be nil. It only has the breaker's error to give
+			process := func(_ context.Context, cmd redis.Cmder) error { return cmd.Err() }
+			return redis.NewScanCmd(ctx, process, "scan", cursor)
+		},
+	)
+}
+
+func (wrapper breakerRedisClient) Close() error {
+	return wrapper.client.Close()
+}
+
+func (wrapper breakerRedisClient) PSubscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	return wrapper.client.PSubscribe(ctx, channels...)
+}
+
+// BreakerMemcachedClient fails fast with errorreference.ErrorCircuitOpen while its breaker is open.
+// Unless settings.IsFailure is set, cache misses and unstored items do not count as failures.
+type BreakerMemcachedClient struct {
+	client  MemcachedClient
+	breaker *circuitbreaker.Breaker
+	logCtx  context.Context // memcached calls carry no context, so state changes are logged with the constructor's logger
+}
+
+var _ MemcachedClient = BreakerMemcachedClient{}
+
+// NewBreakerMemcachedClient wraps client with a breaker. Only the logger is kept from ctx, for logging state changes
+func NewBreakerMemcachedClient(ctx context.Context, client MemcachedClient, settings circuitbreaker.Settings) BreakerMemcachedClient {
+	if settings.IsFailure == nil {
+		settings.IsFailure = func(err error) bool {
+			return err != nil && !errorreference.ErrIsOneOf(err, memcache.ErrCacheMiss, memcache.ErrNotStored)
+		}
+	}
+	return BreakerMemcachedClient{
+		client:  client,
+		breaker: circuitbreaker.New(settings),
+		logCtx:  logging.SetLogger(context.Background(), logging.GetLogger(ctx)),
+	}
+}
+
+func (cache BreakerMemcachedClient) Get(key string) (*memcache.Item, error) {
+	return circuitbreaker.Execute(cache.logCtx, cache.breaker, func(context.Context) (*memcache.Item, error) {
+		return cache.client.Get(key)
+	})
+}
+
+func (cache BreakerMemcachedClient) Set(item *memcache.Item) error {
+	return cache.breaker.Do(cache.logCtx, func(context.Context) error {
+		return cache.client.Set(item)
+	})
+}
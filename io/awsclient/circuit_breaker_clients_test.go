@@ -0,0 +1,56 @@
+package awsclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/redis/go-redis/v9"
+	"github.com/reeceappling/goUtils/v2/circuitbreaker"
+	"github.com/reeceappling/goUtils/v2/errorreference"
+	"github.com/reeceappling/goUtils/v2/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+var errServerDown = errors.New("server down")
+
+type downMemcachedClient struct{}
+
+func (downMemcachedClient) Get(string) (*memcache.Item, error) { return nil, errServerDown }
+func (downMemcachedClient) Set(*memcache.Item) error           { return errServerDown }
+
+// downRedisClient fails every Scan, the other commands are not used
+type downRedisClient struct {
+	WrappedRedisClient
+}
+
+func (downRedisClient) Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+	cmd := redis.NewScanCmd(ctx, nil, "scan", cursor)
+	cmd.SetErr(errServerDown)
+	return cmd
+}
+
+func TestBreakerClients(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("memcached state changes are logged", func(t *testing.T) {
+		log := logging.NewTestLogger(t)
+		cache := NewBreakerMemcachedClient(log.Install(ctx), downMemcachedClient{}, circuitbreaker.Settings{Name: "memcached", MinRequests: 1})
+		_, err := cache.Get("key")
+		assert.ErrorIs(t, err, errServerDown)
+		assert.ErrorIs(t, cache.Set(&memcache.Item{Key: "key"}), errorreference.ErrorCircuitOpen)
+		assert.True(t, log.FieldEquals("circuit breaker changed state", "breaker", "memcached"))
+	})
+
+	t.Run("open redis scans can be iterated", func(t *testing.T) {
+		client := NewBreakerRedisClient(RedisClient{Client: downRedisClient{}}, circuitbreaker.Settings{MinRequests: 1})
+		assert.ErrorIs(t, client.Scan(ctx, 0, "*", 10).Err(), errServerDown)
+
+		cmd := client.Scan(ctx, 0, "*", 10)
+		assert.ErrorIs(t, cmd.Err(), errorreference.ErrorCircuitOpen)
+		iterator := cmd.Iterator()
+		assert.NotPanics(t, func() { assert.False(t, iterator.Next(ctx)) })
+		assert.ErrorIs(t, iterator.Err(), errorreference.ErrorCircuitOpen)
+	})
+}
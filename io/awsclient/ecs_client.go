@@ -10,7 +10,7 @@ import (
 	"os"
 )
 
-const EcsClientKey = "ecs-client-key"
+type ecsClientKey struct{}
 
 //go:generate mockery --name EcsClient
 type EcsClient interface {
@@ -18,7 +18,7 @@ type EcsClient interface {
 }
 
 func GetEcsClient(ctx context.Context) (context.Context, EcsClient, error) {
-	if existingClient, ok := ctx.Value(EcsClientKey).(EcsClient); ok {
+	if existingClient, ok := ctx.Value(ecsClientKey{}).(EcsClient); ok {
 		return ctx, existingClient, nil
 	}
 	cfg, err := config.LoadDefaultConfig(ctx)
@@ -26,7 +26,12 @@ func GetEcsClient(ctx context.Context) (context.Context, EcsClient, error) {
 		return nil, nil, err
 	}
 	var client EcsClient = ecs.NewFromConfig(cfg) // ensures we don't add something that doesn't work with the interface
-	return context.WithValue(ctx, EcsClientKey, client), client, nil
+	return SetEcsClient(ctx, client), client, nil
+}
+
+// SetEcsClient stores client for GetEcsClient to return
+func SetEcsClient(ctx context.Context, client EcsClient) context.Context {
+	return context.WithValue(ctx, ecsClientKey{}, client)
 }
 
 func StopThisTask(ctx context.Context) {
@@ -14,7 +14,7 @@ package awsclient
 //			Cluster: &clusterName,
 //			Task:    utils.Pointer("a-task-id"),
 //		}).Return(nil, nil)
-//		ctx := context.WithValue(ctx, EcsClientKey, mockEcsClient)
+//		ctx := SetEcsClient(ctx, mockEcsClient)
 //		StopThisTask(ctx)
 //	})
 //}
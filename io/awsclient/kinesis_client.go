@@ -6,14 +6,14 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/firehose"
 )
 
-const FirehoseClientKey = "firehose-client-key"
+type firehoseClientKey struct{}
 
 type FirehoseClient interface {
 	PutRecord(ctx context.Context, params *firehose.PutRecordInput, optFns ...func(*firehose.Options)) (*firehose.PutRecordOutput, error)
 }
 
 func GetFirehoseClient(ctx context.Context) (FirehoseClient, error) {
-	if client, ok := ctx.Value(FirehoseClientKey).(FirehoseClient); ok {
+	if client, ok := ctx.Value(firehoseClientKey{}).(FirehoseClient); ok {
 		return client, nil
 	}
 	config, err := AwsConfig.LoadDefaultConfig(ctx)
@@ -24,5 +24,5 @@ func GetFirehoseClient(ctx context.Context) (FirehoseClient, error) {
 }
 
 func SetFirehoseClient(ctx context.Context, client FirehoseClient) context.Context {
-	return context.WithValue(ctx, FirehoseClientKey, client)
+	return context.WithValue(ctx, firehoseClientKey{}, client)
 }
@@ -7,14 +7,14 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/kms"
 )
 
-const KmsClientKey = "kms-client-key"
+type kmsClientKey struct{}
 
 type KmsClient interface {
 	Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error)
 }
 
 func GetKMSClient(ctx context.Context) (context.Context, KmsClient, error) {
-	if existingClient, ok := ctx.Value(KmsClientKey).(KmsClient); ok {
+	if existingClient, ok := ctx.Value(kmsClientKey{}).(KmsClient); ok {
 		return ctx, existingClient, nil
 	}
 	cfg, err := config.LoadDefaultConfig(ctx)
@@ -22,7 +22,12 @@ func GetKMSClient(ctx context.Context) (context.Context, KmsClient, error) {
 		return nil, nil, err
 	}
 	client := kms.NewFromConfig(cfg)
-	return context.WithValue(ctx, KmsClientKey, client), client, nil
+	return SetKMSClient(ctx, client), client, nil
+}
+
+// SetKMSClient stores client for GetKMSClient to return
+func SetKMSClient(ctx context.Context, client KmsClient) context.Context {
+	return context.WithValue(ctx, kmsClientKey{}, client)
 }
 
 func Decrypt(ctx context.Context, encryptedString string) (decryptedString string, err error) {
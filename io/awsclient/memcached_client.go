@@ -85,12 +85,17 @@ func init() {
 	}()
 }
 
-var MemcachedClientKey = "memcached-client-key"
+type memcachedClientKey struct{}
 
 func GetMemcachedClient(ctx context.Context) (context.Context, MemcachedClient) {
-	if existingClient, ok := ctx.Value(MemcachedClientKey).(MemcachedClient); ok {
+	if existingClient, ok := ctx.Value(memcachedClientKey{}).(MemcachedClient); ok {
 		return ctx, existingClient
 	}
 	mc := getClient()
-	return context.WithValue(ctx, MemcachedClientKey, mc), mc
+	return SetMemcachedClient(ctx, mc), mc
+}
+
+// SetMemcachedClient stores client for GetMemcachedClient to return
+func SetMemcachedClient(ctx context.Context, client MemcachedClient) context.Context {
+	return context.WithValue(ctx, memcachedClientKey{}, client)
 }
@@ -6,7 +6,8 @@ import (
 	"time"
 )
 
-const RedisClientKey = "redis-client-key"
+// redisClientKey stores a client per address
+type redisClientKey struct{ addr string }
 
 //go:generate mockery --name WrappedRedisClient
 type WrappedRedisClient interface {
@@ -25,8 +26,7 @@ type RedisClient struct {
 }
 
 func GetRedisClient(ctx context.Context, addr string) (context.Context, RedisClient) {
-	redisClientForAddrKey := RedisClientKey + "-" + addr
-	if existingClient, ok := ctx.Value(redisClientForAddrKey).(RedisClient); ok {
+	if existingClient, ok := ctx.Value(redisClientKey{addr}).(RedisClient); ok {
 		return ctx, existingClient
 	}
 	wrapper := RedisClient{
@@ -37,7 +37,12 @@ func GetRedisClient(ctx context.Context, addr string) (context.Context, RedisCli
 			Addr:         addr,
 		}),
 	}
-	return context.WithValue(ctx, redisClientForAddrKey, wrapper), wrapper
+	return SetRedisClient(ctx, addr, wrapper), wrapper
+}
+
+// SetRedisClient stores client as the client for addr, for GetRedisClient to return
+func SetRedisClient(ctx context.Context, addr string, client RedisClient) context.Context {
+	return context.WithValue(ctx, redisClientKey{addr}, client)
 }
 
 func (wrapper RedisClient) Get(ctx context.Context, key string) ([]byte, error) {
@@ -3,6 +3,7 @@ package awsclient
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
@@ -131,6 +132,10 @@ func StandardizeError(ctx context.Context, err error) error {
 		return ErrorUndefinedS3Key
 	}
 
+	if retry.IsErrorRetryables(retry.DefaultRetryables).IsErrorRetryable(err) == aws.TrueTernary {
+		return fmt.Errorf("%w: %w", errorreference.ErrorTransient, err)
+	}
+
 	return err
 }
 
@@ -6,7 +6,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sns"
 )
 
-var SnsClientKey = "sns-client-key"
+type snsClientKey struct{}
 
 //go:generate mockery --name SnsClient
 type SnsClient interface {
@@ -14,7 +14,7 @@ type SnsClient interface {
 }
 
 func GetSNSClient(ctx context.Context) (context.Context, SnsClient, error) {
-	if existingClient, ok := ctx.Value(SnsClientKey).(SnsClient); ok {
+	if existingClient, ok := ctx.Value(snsClientKey{}).(SnsClient); ok {
 		return ctx, existingClient, nil
 	}
 	cfg, err := config.LoadDefaultConfig(ctx)
@@ -22,5 +22,10 @@ func GetSNSClient(ctx context.Context) (context.Context, SnsClient, error) {
 		return nil, nil, err
 	}
 	client := sns.NewFromConfig(cfg)
-	return context.WithValue(ctx, SnsClientKey, client), client, nil
+	return SetSNSClient(ctx, client), client, nil
+}
+
+// SetSNSClient stores client for GetSNSClient to return
+func SetSNSClient(ctx context.Context, client SnsClient) context.Context {
+	return context.WithValue(ctx, snsClientKey{}, client)
 }
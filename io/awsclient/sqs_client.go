@@ -6,7 +6,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 )
 
-var SqsClientKey = "sqs-client-key"
+type sqsClientKey struct{}
 
 //go:generate mockery --name SqsClient
 type SqsClient interface {
@@ -15,7 +15,7 @@ type SqsClient interface {
 }
 
 func GetSQSClient(ctx context.Context) (context.Context, SqsClient, error) {
-	if existingClient, ok := ctx.Value(SqsClientKey).(SqsClient); ok {
+	if existingClient, ok := ctx.Value(sqsClientKey{}).(SqsClient); ok {
 		return ctx, existingClient, nil
 	}
 	cfg, err := config.LoadDefaultConfig(ctx)
@@ -23,5 +23,10 @@ func GetSQSClient(ctx context.Context) (context.Context, SqsClient, error) {
 		return nil, nil, err
 	}
 	client := sqs.NewFromConfig(cfg)
-	return context.WithValue(ctx, SqsClientKey, client), client, nil
+	return SetSQSClient(ctx, client), client, nil
+}
+
+// SetSQSClient stores client for GetSQSClient to return
+func SetSQSClient(ctx context.Context, client SqsClient) context.Context {
+	return context.WithValue(ctx, sqsClientKey{}, client)
 }
@@ -1,26 +1,44 @@
 package awsclient
 
-//type mockSqsClient struct { // TODO: REENABLE LATER
-//}
-//
-//func (mock mockSqsClient) ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
-//	return nil, errors.New("not implemented")
-//}
-//func (mock mockSqsClient) DeleteMessage(ctx context.Context, params *sqs.DeleteMessageInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error) {
-//	return nil, errors.New("not implemented")
-//}
-//
-//func TestDefaultSqsClient(t *testing.T) {
-//
-//	mockClient := mockSqsClient{}
-//	contextWithoutSqsClient := context.Background()
-//	contextWithSqsClient := context.WithValue(contextWithoutSqsClient, SqsClientKey, mockClient)
-//
-//	t.Run("load already existing client", func(t *testing.T) {
-//		context, client, error := GetSQSClient(contextWithSqsClient)
-//
-//		assert.Nil(t, error)
-//		assert.Equal(t, mockClient, client)
-//		assert.Equal(t, contextWithSqsClient, context)
-//	})
-//}
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSqsClient struct {
+	name string
+}
+
+func (mock mockSqsClient) ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+	return nil, errors.New("not implemented")
+}
+func (mock mockSqsClient) DeleteMessage(ctx context.Context, params *sqs.DeleteMessageInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestDefaultSqsClient(t *testing.T) {
+
+	mockClient := mockSqsClient{name: "mock"}
+	contextWithoutSqsClient := context.Background()
+	contextWithSqsClient := SetSQSClient(contextWithoutSqsClient, mockClient)
+
+	t.Run("load already existing client", func(t *testing.T) {
+		ctx, client, err := GetSQSClient(contextWithSqsClient)
+
+		assert.Nil(t, err)
+		assert.Equal(t, mockClient, client)
+		assert.Equal(t, contextWithSqsClient, ctx)
+	})
+
+	t.Run("string keys do not collide", func(t *testing.T) {
+		ctx := context.WithValue(contextWithSqsClient, "sqs-client-key", mockSqsClient{name: "other"}) //nolint:staticcheck
+		_, client, err := GetSQSClient(ctx)
+
+		assert.Nil(t, err)
+		assert.Equal(t, mockClient, client)
+	})
+}
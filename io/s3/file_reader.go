@@ -3,7 +3,6 @@ package s3
 import (
 	"context"
 	"errors"
-	"github.com/reeceappling/goUtils/v2/errorreference"
 	"github.com/reeceappling/goUtils/v2/io/awsclient"
 	recover2 "github.com/reeceappling/goUtils/v2/recover"
 	"github.com/reeceappling/goUtils/v2/utils"
@@ -30,53 +29,50 @@ func (reader *S3FileReader) Read(ctx context.Context, path string) (output []byt
 
 	select {
 	case res := <-firstChan:
-		return res.Item, res.Err
+		return res.Get()
 	case <-time.After(2 * time.Second): // slightly less arbitrary, basically everything completes before this
 		return lazyRace(ctx, path, reader, firstChan)
 	}
 }
 
-func lazyRace(ctx context.Context, path string, reader *S3FileReader, firstChan <-chan utils.ErrAnd[[]byte]) (output []byte, err error) {
+func lazyRace(ctx context.Context, path string, reader *S3FileReader, firstChan <-chan utils.Result[[]byte]) (output []byte, err error) {
 	secondChan := backgroundRead(ctx, path, reader)
 	select {
 	case res := <-firstChan:
 		if res.Err != nil {
 			return handleBackupChan(res.Err, secondChan)
 		}
-		return res.Item, nil
+		return res.Get()
 	case res := <-secondChan:
 		if res.Err != nil {
 			return handleBackupChan(res.Err, firstChan)
 		}
-		return res.Item, nil
+		return res.Get()
 	}
 }
-func backgroundRead(ctx context.Context, path string, reader *S3FileReader) <-chan utils.ErrAnd[[]byte] {
-	out := make(chan utils.ErrAnd[[]byte], 1)
+func backgroundRead(ctx context.Context, path string, reader *S3FileReader) <-chan utils.Result[[]byte] {
+	out := make(chan utils.Result[[]byte], 1)
 	go func() {
 		defer close(out)
 		r, contentLength, err := reader.ReadStreaming(ctx, path)
 		if err != nil {
-			out <- utils.ErrAnd[[]byte]{Err: err}
+			out <- utils.ErroredResult[[]byte](err)
 			return
 		}
 		defer r.Close() //nolint:errcheck
 		output := make([]byte, contentLength)
 		_, err = goio.ReadFull(r, output)
-		out <- utils.ErrAnd[[]byte]{
-			Item: output,
-			Err:  err,
-		}
+		out <- utils.ResultFrom(output, err)
 	}()
 	return out
 }
 
-func handleBackupChan(err error, c <-chan utils.ErrAnd[[]byte]) ([]byte, error) {
+func handleBackupChan(err error, c <-chan utils.Result[[]byte]) ([]byte, error) {
 	other := <-c
 	if other.Err != nil {
 		return nil, errors.Join(err, other.Err)
 	}
-	return other.Item, nil
+	return other.Get()
 }
 
 func (reader *S3FileReader) ReadStreaming(ctx context.Context, path string) (output goio.ReadCloser, contentLength int64, err error) {
@@ -85,78 +81,42 @@ func (reader *S3FileReader) ReadStreaming(ctx context.Context, path string) (out
 
 	bucket := reader.Bucket
 
-	var res *s3.GetObjectOutput
-	for i := 0; i < clientConfig.MaxReadRetries; i++ {
-		res, err = client.GetObject(
+	policy := retryPolicy(clientConfig.MaxReadRetries)
+	res, err := utils.RetryCtx(ctx, policy, func(ctx context.Context) (*s3.GetObjectOutput, error) {
+		return client.GetObject(
 			ctx,
 			&s3.GetObjectInput{Bucket: &bucket, Key: &path},
 		)
-
-		if err == nil { // success. no other tests needed
-			return res.Body, *res.ContentLength, nil
-		}
-
-		if errors.Is(err, context.Canceled) {
-			return nil, 0, err // the request is aborted
-		}
-
-		if errors.Is(err, errorreference.ErrorNotFound) {
-			return nil, 0, err
-		}
-
-		if !errors.Is(err, errorreference.ErrorSlowDown) {
-			return nil, 0, err // unexpected/unhandled, catastrophic error
-		} else {
-			// TODO: log.Sugar().Warn("ErrorSlowDown from s3")
-		}
-
-		time.Sleep(utils.Jitter()) // retry after delay
+	})
+	if err != nil {
+		return nil, 0, err
 	}
-
-	return nil, 0, err
+	return res.Body, *res.ContentLength, nil
 }
 
 func (reader *S3FileReader) List(ctx context.Context, path string) (list []string, err error) {
 	clientConfig := awsclient.GetClientConfig()
 	client := awsclient.GetS3Client()
 
-	for i := 0; i < clientConfig.MaxListRetries; i++ {
-		list = []string{}
+	policy := retryPolicy(clientConfig.MaxListRetries)
+	return utils.RetryCtx(ctx, policy, func(ctx context.Context) ([]string, error) {
+		list := []string{}
 		paginator := s3.NewListObjectsV2Paginator(
 			client,
 			&s3.ListObjectsV2Input{Bucket: &reader.Bucket, Prefix: &path},
 		)
 
-		var page *s3.ListObjectsV2Output
 		for paginator.HasMorePages() {
-			page, err = paginator.NextPage(ctx)
-			if err == nil { // success case
-				for _, item := range page.Contents { // aggregate results
-					list = append(list, *item.Key)
-				}
-				continue
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
 			}
-
-			if errors.Is(err, context.Canceled) {
-				// TODO: log.Sugar().Debugw("Canceled S3 List")
-				return // the request is aborted
+			for _, item := range page.Contents { // aggregate results
+				list = append(list, *item.Key)
 			}
-
-			if errors.Is(err, errorreference.ErrorSlowDown) {
-				break // return to outer loop to try again
-			} // else fallthrough
-
-			// TODO: log.Sugar().Errorw("Unable to do S3 List", "error", err)
-			return // unexpected/unhandled, catastrophic error
 		}
-		if err == nil {
-			return // complete without error
-		}
-
-		time.Sleep(utils.Jitter()) // retry after delay
-	}
-
-	return
+		return list, nil
+	})
 }
 
 func (reader *S3FileReader) RaceRead(ctx context.Context, path string) ([]byte, error) {
@@ -176,18 +136,18 @@ func (reader *S3FileReader) RaceReadN(ctx context.Context, path string, concurre
 	for i := 0; i < concurrentReads; i++ {
 		chans[i] = reader.readerProducer(ctx, path)
 	}
-	channel := channels.Multiplex(chans)
-	defer func() {
-		cancel()
-		channels.Drain(channel)
-	}()
+	channel := channels.Merge(ctx, chans...)
+	defer cancel() // producers are buffered, so they finish without anyone reading them
 
 	var err error
 	for i := 0; i < concurrentReads; i++ {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
-		case res := <-channel:
+		case res, ok := <-channel:
+			if !ok {
+				return nil, ctx.Err()
+			}
 			if res.err == nil {
 				return res.data, res.err
 			}
@@ -203,17 +163,16 @@ func (reader *S3FileReader) RaceReadN(ctx context.Context, path string, concurre
 }
 
 func (reader *S3FileReader) readerProducer(ctx context.Context, path string) <-chan s3Data {
-	output := make(chan s3Data)
+	output := make(chan s3Data, 1)
 
+	var bytes []byte
+	done := recover2.Go(ctx, func(ctx context.Context) (err error) {
+		bytes, err = reader.Read(ctx, path)
+		return err
+	})
 	go func() {
 		defer close(output)
-		defer func() {
-			if err := recover2.HandleRecoverAndLog(ctx, recover()); err != nil { // TODO: EW
-				output <- s3Data{data: nil, err: err}
-			}
-		}()
-		bytes, err := reader.Read(ctx, path)
-
+		err := <-done
 		output <- s3Data{bytes, err}
 	}()
 
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	goio "io"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -342,8 +343,10 @@ func TestS3FileReaderList(t *testing.T) {
 }
 
 func TestRaceRead(t *testing.T) {
+	var finished atomic.Int64
 	s3Client := &MockS3Client{
 		MockGetObject: func(ctx context.Context, input *s3.GetObjectInput, f ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+			defer finished.Add(1)
 			// give it enough time to hit the other side of the select
 			// as well as simulate a real network call
 			// closing the Done channel in the context can happen asynchronously
@@ -365,6 +368,9 @@ func TestRaceRead(t *testing.T) {
 
 		_, err := reader.RaceRead(ctx, "")
 		assert.ErrorIs(t, err, context.Canceled)
+
+		// RaceRead returns without waiting for its reads, so wait here rather than leave them to later tests
+		assert.Eventually(t, func() bool { return finished.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
 	})
 }
 
@@ -0,0 +1,40 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/reeceappling/goUtils/v2/date"
+	"github.com/reeceappling/goUtils/v2/utils"
+	"github.com/reeceappling/goUtils/v2/utils/slices"
+)
+
+// ListRange lists every object under the partition of each day in days, running up to concurrency List calls at once.
+// Keys are returned in day order. If any day fails, the errors of every failed day are returned joined.
+func (reader *S3FileReader) ListRange(ctx context.Context, template date.PartitionTemplate, days date.DateRange, concurrency int) ([]string, error) {
+	prefixes := template.RenderRange(days)
+	perDay, err := utils.CollectResults(slices.ParallelMap(ctx, prefixes, concurrency, reader.List))
+	if err != nil {
+		return nil, fmt.Errorf("listing %s over %s: %w", template, days, err)
+	}
+	return slices.Flatten(perDay), nil
+}
+
+// ReadRange reads every object ListRange finds, running up to concurrency Read calls at once, returning the data by key.
+// Objects that fail to read are left out of the map and their errors are returned joined.
+func (reader *S3FileReader) ReadRange(ctx context.Context, template date.PartitionTemplate, days date.DateRange, concurrency int) (map[string][]byte, error) {
+	keys, err := reader.ListRange(ctx, template, days, concurrency)
+	if err != nil {
+		return nil, err
+	}
+	data, errs := make(map[string][]byte, len(keys)), []error{}
+	for i, result := range slices.ParallelMap(ctx, keys, concurrency, reader.Read) {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("reading %s: %w", keys[i], result.Err))
+			continue
+		}
+		data[keys[i]] = result.Must()
+	}
+	return data, errors.Join(errs...)
+}
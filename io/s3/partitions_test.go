@@ -0,0 +1,93 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	goio "io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/reeceappling/goUtils/v2/date"
+	"github.com/reeceappling/goUtils/v2/io/awsclient"
+	"github.com/reeceappling/goUtils/v2/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3FileReaderRange(t *testing.T) {
+	template := date.HivePartitions("events")
+	days := date.DateRange{Start: date.MustParse("2025-01-30"), End: date.MustParse("2025-02-02")}
+	previousClient, clientConfig := awsclient.GetS3Client(), awsclient.GetClientConfig()
+	t.Cleanup(func() {
+		awsclient.SetS3Client(previousClient)
+		awsclient.SetClientConfig(clientConfig)
+	})
+	clientConfig.MaxListRetries = 1
+	clientConfig.MaxReadRetries = 1
+	awsclient.SetClientConfig(clientConfig)
+
+	var listing, maxListing atomic.Int64
+	listing1PerDay := func(ctx context.Context, input *s3.ListObjectsV2Input, f ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+		now := listing.Add(1)
+		defer listing.Add(-1)
+		for old := maxListing.Load(); now > old && !maxListing.CompareAndSwap(old, now); old = maxListing.Load() {
+		}
+		time.Sleep(5 * time.Millisecond)
+		if strings.Contains(*input.Prefix, "dd=01") {
+			return &s3.ListObjectsV2Output{IsTruncated: utils.Pointer(false)}, nil // an empty day
+		}
+		key := *input.Prefix + "part-0"
+		return &s3.ListObjectsV2Output{IsTruncated: utils.Pointer(false), Contents: []types.Object{{Key: &key}}}, nil
+	}
+
+	t.Run("ListRange", func(t *testing.T) {
+		awsclient.SetS3Client(&MockS3Client{MockListObjectsV2: listing1PerDay})
+		keys, err := NewFileReader("bucket").ListRange(context.Background(), template, days, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"events/yyyy=2025/mm=01/dd=30/part-0",
+			"events/yyyy=2025/mm=01/dd=31/part-0",
+			"events/yyyy=2025/mm=02/dd=02/part-0",
+		}, keys, "keys are in day order")
+		assert.LessOrEqual(t, maxListing.Load(), int64(2))
+	})
+
+	t.Run("ListRange fails if any day fails", func(t *testing.T) {
+		apocalypse := errors.New("apocalypse")
+		awsclient.SetS3Client(&MockS3Client{
+			MockListObjectsV2: func(ctx context.Context, input *s3.ListObjectsV2Input, f ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+				if strings.Contains(*input.Prefix, "dd=31") {
+					return nil, apocalypse
+				}
+				return listing1PerDay(ctx, input, f...)
+			},
+		})
+		keys, err := NewFileReader("bucket").ListRange(context.Background(), template, days, 2)
+		assert.Nil(t, keys)
+		assert.ErrorIs(t, err, apocalypse)
+	})
+
+	t.Run("ReadRange", func(t *testing.T) {
+		unreadable := errors.New("unreadable")
+		awsclient.SetS3Client(&MockS3Client{
+			MockListObjectsV2: listing1PerDay,
+			MockGetObject: func(ctx context.Context, input *s3.GetObjectInput, f ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+				if strings.Contains(*input.Key, "dd=31") {
+					return nil, unreadable
+				}
+				body := strings.NewReader(*input.Key)
+				return &s3.GetObjectOutput{Body: goio.NopCloser(body), ContentLength: utils.Pointer(body.Size())}, nil
+			},
+		})
+		data, err := NewFileReader("bucket").ReadRange(context.Background(), template, days, 2)
+		assert.ErrorIs(t, err, unreadable)
+		assert.ErrorContains(t, err, "events/yyyy=2025/mm=01/dd=31/part-0")
+		assert.Equal(t, map[string][]byte{
+			"events/yyyy=2025/mm=01/dd=30/part-0": []byte("events/yyyy=2025/mm=01/dd=30/part-0"),
+			"events/yyyy=2025/mm=02/dd=02/part-0": []byte("events/yyyy=2025/mm=02/dd=02/part-0"),
+		}, data, "objects that could be read are still returned")
+	})
+}
@@ -0,0 +1,20 @@
+package s3
+
+import (
+	"context"
+	"github.com/reeceappling/goUtils/v2/errorreference"
+	"github.com/reeceappling/goUtils/v2/logging"
+	"github.com/reeceappling/goUtils/v2/utils"
+	"time"
+)
+
+// retryPolicy is the policy shared by the reader and writer loops.
+// Only throttled and transient errors are retried, and each retry is logged at debug level
+func retryPolicy(maxAttempts int) utils.RetryPolicy {
+	policy := utils.DefaultRetryPolicy()
+	policy.MaxAttempts = max(maxAttempts, 1)
+	policy.OnRetry = func(ctx context.Context, attempt int, err error, wait time.Duration) {
+		logging.GetSugaredLogger(ctx).Debugw("retrying s3 call", "attempt", attempt, "class", errorreference.Classify(err).String(), "wait", wait, "error", err)
+	}
+	return policy
+}
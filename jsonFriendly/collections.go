@@ -0,0 +1,60 @@
+package jsonFriendly
+
+// JsonFloat is any of this package's float types
+type JsonFloat interface {
+	~float32 | ~float64
+	MarshalJSON() ([]byte, error)
+}
+
+// rawFloat is any float, e.g. float64 or a named float type
+type rawFloat interface {
+	~float32 | ~float64
+}
+
+// Wrap converts a slice of floats to one of this package's types, e.g. Wrap[Float](values)
+func Wrap[J JsonFloat, F rawFloat](in []F) []J {
+	if in == nil {
+		return nil
+	}
+	out := make([]J, len(in))
+	for i, v := range in {
+		out[i] = J(v)
+	}
+	return out
+}
+
+// Unwrap converts a slice of this package's floats back, e.g. Unwrap[float64](values)
+func Unwrap[F rawFloat, J JsonFloat](in []J) []F {
+	if in == nil {
+		return nil
+	}
+	out := make([]F, len(in))
+	for i, v := range in {
+		out[i] = F(v)
+	}
+	return out
+}
+
+// WrapMap is Wrap for map values, e.g. WrapMap[Float](values)
+func WrapMap[J JsonFloat, K comparable, F rawFloat](in map[K]F) map[K]J {
+	if in == nil {
+		return nil
+	}
+	out := make(map[K]J, len(in))
+	for k, v := range in {
+		out[k] = J(v)
+	}
+	return out
+}
+
+// UnwrapMap is Unwrap for map values, e.g. UnwrapMap[float64](values)
+func UnwrapMap[F rawFloat, K comparable, J JsonFloat](in map[K]J) map[K]F {
+	if in == nil {
+		return nil
+	}
+	out := make(map[K]F, len(in))
+	for k, v := range in {
+		out[k] = F(v)
+	}
+	return out
+}
@@ -0,0 +1,159 @@
+package jsonFriendly
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// Encoding is the JSON written for non-finite floats. Each field must be valid JSON other than null.
+// Float, Float32 and NullableFloat write DefaultEncoding, while FloatWith and Float32With take theirs as a type
+// parameter. Every type reads the names in DefaultEncoding and InfinityEncoding, quoted or bare, as well as its own
+type Encoding struct {
+	NaN    string
+	PosInf string
+	NegInf string
+}
+
+var (
+	// DefaultEncoding writes "NaN", "+Inf" and "-Inf"
+	DefaultEncoding = Encoding{NaN: NaNFloatString, PosInf: InfPosFloatString, NegInf: InfNegFloatString}
+	// InfinityEncoding writes "NaN", "Infinity" and "-Infinity", the names JavaScript and Python use
+	InfinityEncoding = Encoding{NaN: NaNFloatString, PosInf: `"Infinity"`, NegInf: `"-Infinity"`}
+)
+
+// EncodingType names an Encoding as a type, so FloatWith and Float32With can be given it as a type parameter.
+// Define an empty struct with an Encoding method to use another Encoding
+type EncodingType interface {
+	Encoding() Encoding
+}
+
+// UseDefault names DefaultEncoding, e.g. FloatWith[UseDefault]
+type UseDefault struct{}
+
+func (UseDefault) Encoding() Encoding { return DefaultEncoding }
+
+// UseInfinity names InfinityEncoding, e.g. FloatWith[UseInfinity]
+type UseInfinity struct{}
+
+func (UseInfinity) Encoding() Encoding { return InfinityEncoding }
+
+type validatedEncoding struct {
+	encoding Encoding
+	err      error
+}
+
+var encodings sync.Map // the reflect.Type of an EncodingType to its validatedEncoding
+
+// encodingOf returns the Encoding E names, validated once per type
+func encodingOf[E EncodingType]() (Encoding, error) {
+	t := reflect.TypeFor[E]()
+	if cached, ok := encodings.Load(t); ok {
+		return cached.(validatedEncoding).encoding, cached.(validatedEncoding).err
+	}
+	var e E
+	encoding, err := e.Encoding().validate()
+	encodings.Store(t, validatedEncoding{encoding: encoding, err: err})
+	return encoding, err
+}
+
+// validate returns e with its values compacted, or an error if they cannot be read back unambiguously.
+// Each value must be valid JSON other than null, must differ from the other two, and must not read back as a different
+// number, e.g. NaN cannot be written as 0 or as "+Inf".
+func (e Encoding) validate() (Encoding, error) {
+	tokens := []struct {
+		value *string
+		want  float64
+	}{{&e.NaN, math.NaN()}, {&e.PosInf, math.Inf(1)}, {&e.NegInf, math.Inf(-1)}}
+	for _, token := range tokens {
+		if !json.Valid([]byte(*token.value)) || *token.value == "null" {
+			return e, fmt.Errorf("invalid encoding %q: it must be valid JSON other than null", *token.value)
+		}
+		*token.value = compact(*token.value)
+		if val, err := parseFloat([]byte(*token.value), 64); err == nil && !sameFloat(*val, token.want) {
+			return e, fmt.Errorf("invalid encoding %q: it would be read back as %v", *token.value, *val)
+		}
+	}
+	if e.NaN == e.PosInf || e.NaN == e.NegInf || e.PosInf == e.NegInf {
+		return e, fmt.Errorf("invalid encoding %+v: NaN, PosInf and NegInf must be written differently", e)
+	}
+	return e, nil
+}
+
+// decode reads input if it is one of e's values
+func (e Encoding) decode(input []byte) (float64, bool) {
+	if len(input) > 0 && (input[0] == '{' || input[0] == '[') {
+		input = []byte(compact(string(input)))
+	}
+	switch string(input) {
+	case e.NaN:
+		return math.NaN(), true
+	case e.PosInf:
+		return math.Inf(1), true
+	case e.NegInf:
+		return math.Inf(-1), true
+	}
+	return 0, false
+}
+
+// compact strips insignificant whitespace from valid JSON, so values can be compared as strings
+func compact(value string) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(value)); err != nil {
+		return value
+	}
+	return buf.String()
+}
+
+func sameFloat(a, b float64) bool {
+	return a == b || math.IsNaN(a) && math.IsNaN(b)
+}
+
+// QuoteNonFinite quotes the bare NaN, Infinity and -Infinity tokens that Python's json module writes, which are not
+// valid JSON, so encoding/json can decode them into this package's float types. Strings are left untouched.
+func QuoteNonFinite(data []byte) []byte {
+	var out []byte // only allocated once a token needs quoting
+	inString, escaped, copied := false, false, 0
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		if c == '"' {
+			inString = true
+			continue
+		}
+		for _, token := range [][]byte{[]byte("NaN"), []byte("Infinity"), []byte("-Infinity")} {
+			if !bytes.HasPrefix(data[i:], token) {
+				continue
+			}
+			end := i + len(token)
+			if end < len(data) && isTokenByte(data[end]) {
+				continue
+			}
+			out = append(out, data[copied:i]...)
+			out = append(append(append(out, '"'), token...), '"')
+			i, copied = end-1, end
+			break
+		}
+	}
+	if out == nil {
+		return data
+	}
+	return append(out, data[copied:]...)
+}
+
+func isTokenByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
@@ -18,34 +18,154 @@ const (
 type Float float64
 
 func (jff Float) MarshalJSON() ([]byte, error) {
-	val := float64(jff)
-	if math.IsNaN(val) {
-		return []byte(NaNFloatString), nil
+	return marshalFloat(float64(jff), 64, DefaultEncoding), nil
+}
+
+func (jff *Float) UnmarshalJSON(input []byte) error {
+	val, err := unmarshalFloat(input, 64, DefaultEncoding)
+	if err != nil {
+		return err
 	}
-	if math.IsInf(val, 1) {
-		return []byte(InfPosFloatString), nil
+	if val != nil {
+		*jff = Float(*val)
 	}
-	if math.IsInf(val, -1) {
-		return []byte(InfNegFloatString), nil
+	return nil
+}
+
+// Float32 is Float for float32 values
+type Float32 float32
+
+func (jff Float32) MarshalJSON() ([]byte, error) {
+	return marshalFloat(float64(jff), 32, DefaultEncoding), nil
+}
+
+func (jff *Float32) UnmarshalJSON(input []byte) error {
+	val, err := unmarshalFloat(input, 32, DefaultEncoding)
+	if err != nil {
+		return err
+	}
+	if val != nil {
+		*jff = Float32(*val)
 	}
-	return []byte(strconv.FormatFloat(val, 'g', -1, 64)), nil
+	return nil
 }
 
-func (jff *Float) UnmarshalJSON(input []byte) error {
+// NullableFloat is a Float that writes NaN as null and reads null as NaN, for readers treating NaN as a missing value
+type NullableFloat float64
+
+func (jff NullableFloat) MarshalJSON() ([]byte, error) {
+	if math.IsNaN(float64(jff)) {
+		return []byte("null"), nil
+	}
+	return marshalFloat(float64(jff), 64, DefaultEncoding), nil
+}
+
+func (jff *NullableFloat) UnmarshalJSON(input []byte) error {
+	val, err := unmarshalFloat(input, 64, DefaultEncoding)
+	if err != nil {
+		return err
+	}
+	if val == nil {
+		*jff = NullableFloat(math.NaN())
+		return nil
+	}
+	*jff = NullableFloat(*val)
+	return nil
+}
+
+// FloatWith is Float written with the Encoding E names, e.g. FloatWith[UseInfinity]
+type FloatWith[E EncodingType] float64
+
+func (jff FloatWith[E]) MarshalJSON() ([]byte, error) {
+	encoding, err := encodingOf[E]()
+	if err != nil {
+		return nil, err
+	}
+	return marshalFloat(float64(jff), 64, encoding), nil
+}
+
+func (jff *FloatWith[E]) UnmarshalJSON(input []byte) error {
+	encoding, err := encodingOf[E]()
+	if err != nil {
+		return err
+	}
+	val, err := unmarshalFloat(input, 64, encoding)
+	if err != nil {
+		return err
+	}
+	if val != nil {
+		*jff = FloatWith[E](*val)
+	}
+	return nil
+}
+
+// Float32With is Float32 written with the Encoding E names, e.g. Float32With[UseInfinity]
+type Float32With[E EncodingType] float32
+
+func (jff Float32With[E]) MarshalJSON() ([]byte, error) {
+	encoding, err := encodingOf[E]()
+	if err != nil {
+		return nil, err
+	}
+	return marshalFloat(float64(jff), 32, encoding), nil
+}
+
+func (jff *Float32With[E]) UnmarshalJSON(input []byte) error {
+	encoding, err := encodingOf[E]()
+	if err != nil {
+		return err
+	}
+	val, err := unmarshalFloat(input, 32, encoding)
+	if err != nil {
+		return err
+	}
+	if val != nil {
+		*jff = Float32With[E](*val)
+	}
+	return nil
+}
+
+func marshalFloat(val float64, bitSize int, encoding Encoding) []byte {
+	switch {
+	case math.IsNaN(val):
+		return []byte(encoding.NaN)
+	case math.IsInf(val, 1):
+		return []byte(encoding.PosInf)
+	case math.IsInf(val, -1):
+		return []byte(encoding.NegInf)
+	}
+	return []byte(strconv.FormatFloat(val, 'g', -1, bitSize))
+}
+
+// unmarshalFloat reads anything parseFloat does, and the non-finite values of encoding.
+// It returns nil for null, which callers may leave as a no-op as encoding/json does
+func unmarshalFloat(input []byte, bitSize int, encoding Encoding) (*float64, error) {
+	val, err := parseFloat(input, bitSize)
+	if err != nil {
+		if nonFinite, ok := encoding.decode(input); ok {
+			return &nonFinite, nil
+		}
+	}
+	return val, err
+}
+
+// parseFloat reads a JSON number, or a non-finite value named as strconv.ParseFloat does, quoted or bare, e.g. "NaN",
+// NaN, "+Inf" or Infinity. It returns nil for null
+func parseFloat(input []byte, bitSize int) (*float64, error) {
 	str := string(input)
-	switch str {
-	case NaNFloatString:
-		*jff = Float(math.NaN())
-	case InfPosFloatString:
-		*jff = Float(math.Inf(1))
-	case InfNegFloatString:
-		*jff = Float(math.Inf(-1))
-	default:
-		val, err := strconv.ParseFloat(str, 64)
-		if err != nil {
-			return err
+	if str == "null" {
+		return nil, nil
+	}
+	if unquoted, err := strconv.Unquote(str); err == nil && len(str) > 0 && str[0] == '"' {
+		val, err := strconv.ParseFloat(unquoted, bitSize)
+		if err != nil || !math.IsNaN(val) && !math.IsInf(val, 0) { // only non-finite values may be quoted
+			return nil, &strconv.NumError{Func: "ParseFloat", Num: str, Err: strconv.ErrSyntax}
 		}
-		*jff = Float(val)
+		return &val, nil
 	}
-	return nil
+	val, err := strconv.ParseFloat(str, bitSize)
+	if err != nil {
+		return nil, err
+	}
+	return &val, nil
 }
@@ -2,7 +2,9 @@ package jsonFriendly
 
 import (
 	"encoding/json"
+	"github.com/reeceappling/goUtils/v2/utils/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"math"
 	"testing"
 )
@@ -34,3 +36,179 @@ func TestJsonFriendlyFloats(t *testing.T) {
 		testJff(t, math.Inf(2))
 	})
 }
+
+func TestEncodings(t *testing.T) {
+	type payload struct {
+		A Float         `json:"a"`
+		B Float32       `json:"b"`
+		C NullableFloat `json:"c"`
+		D NullableFloat `json:"d"`
+	}
+	nonFinite := payload{A: Float(math.Inf(1)), B: Float32(math.Inf(-1)), C: NullableFloat(math.NaN()), D: NullableFloat(math.Inf(1))}
+
+	t.Run("default", func(t *testing.T) {
+		out, err := json.Marshal(nonFinite)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"a":"+Inf","b":"-Inf","c":null,"d":"+Inf"}`, string(out))
+	})
+	t.Run("per type", func(t *testing.T) {
+		type python struct {
+			A FloatWith[UseInfinity]   `json:"a"`
+			B Float32With[UseInfinity] `json:"b"`
+			C Float                    `json:"c"`
+		}
+		out, err := json.Marshal(python{A: FloatWith[UseInfinity](math.Inf(1)), B: Float32With[UseInfinity](math.Inf(-1)), C: Float(math.Inf(1))})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"a":"Infinity","b":"-Infinity","c":"+Inf"}`, string(out), "each type keeps its own Encoding")
+
+		var decoded python
+		assert.NoError(t, json.Unmarshal(out, &decoded))
+		assert.True(t, math.IsInf(float64(decoded.A), 1))
+		assert.True(t, math.IsInf(float64(decoded.B), -1))
+		assert.True(t, math.IsInf(float64(decoded.C), 1), "the built-in names are read by every type")
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for reason, e := range map[string]Encoding{
+			"bare NaN is not valid JSON": {NaN: "NaN", PosInf: InfPosFloatString, NegInf: InfNegFloatString},
+			"null":                       {NaN: "null", PosInf: InfPosFloatString, NegInf: InfNegFloatString},
+			"0 reads back as 0":          {NaN: "0", PosInf: InfPosFloatString, NegInf: InfNegFloatString},
+			"reads back as +Inf":         {NaN: InfPosFloatString, PosInf: `"Infinity"`, NegInf: InfNegFloatString},
+			"ambiguous":                  {NaN: `"x"`, PosInf: `"x"`, NegInf: InfNegFloatString},
+		} {
+			_, err := e.validate()
+			assert.Error(t, err, reason)
+		}
+		_, err := json.Marshal(FloatWith[ambiguous](1))
+		assert.Error(t, err)
+		assert.Error(t, json.Unmarshal([]byte(`1`), new(Float32With[ambiguous])))
+	})
+	t.Run("custom round trip", func(t *testing.T) {
+		out, err := json.Marshal(map[string][]FloatWith[objects]{"a": {FloatWith[objects](math.NaN()), FloatWith[objects](math.Inf(1)), FloatWith[objects](math.Inf(-1)), 0}})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"a":["missing",{"inf":1},{"inf":-1},0]}`, string(out))
+
+		var decoded map[string][]FloatWith[objects]
+		assert.NoError(t, json.Unmarshal([]byte(`{"a": ["missing", {"inf": 1}, { "inf" : -1 }, 0, "NaN"]}`), &decoded))
+		assert.True(t, math.IsNaN(float64(decoded["a"][0])))
+		assert.Equal(t, []FloatWith[objects]{FloatWith[objects](math.Inf(1)), FloatWith[objects](math.Inf(-1)), 0}, decoded["a"][1:4])
+		assert.True(t, math.IsNaN(float64(decoded["a"][4])), "the built-in names are still read")
+		var f32 Float32With[objects]
+		assert.NoError(t, json.Unmarshal([]byte(`"missing"`), &f32))
+		assert.True(t, math.IsNaN(float64(f32)))
+		assert.Error(t, json.Unmarshal([]byte(`"missing"`), new(Float)), "only the type naming the Encoding reads it")
+	})
+}
+
+type ambiguous struct{}
+
+func (ambiguous) Encoding() Encoding {
+	return Encoding{NaN: `"x"`, PosInf: `"x"`, NegInf: InfNegFloatString}
+}
+
+type objects struct{}
+
+func (objects) Encoding() Encoding {
+	return Encoding{NaN: `"missing"`, PosInf: `{ "inf": 1 }`, NegInf: `{"inf": -1}`}
+}
+
+func TestUnmarshalAcceptsEveryForm(t *testing.T) {
+	tests := map[string]float64{
+		`"NaN"`: math.NaN(), `"nan"`: math.NaN(), `NaN`: math.NaN(),
+		`"+Inf"`: math.Inf(1), `"Infinity"`: math.Inf(1), `Infinity`: math.Inf(1), `"inf"`: math.Inf(1),
+		`"-Inf"`: math.Inf(-1), `"-Infinity"`: math.Inf(-1), `-Infinity`: math.Inf(-1),
+		`1.5`: 1.5, `-2e3`: -2000,
+	}
+	for input, exp := range tests {
+		var f Float
+		assert.NoError(t, f.UnmarshalJSON([]byte(input)), input)
+		test.NaNEqual(t, exp, float64(f))
+
+		var f32 Float32
+		assert.NoError(t, f32.UnmarshalJSON([]byte(input)), input)
+		test.NaNEqual(t, exp, float64(f32))
+	}
+	for _, bad := range []string{`"1.5"`, `"abc"`, `abc`, `""`} {
+		var f Float
+		assert.Error(t, f.UnmarshalJSON([]byte(bad)), bad)
+	}
+
+	var f Float = 3
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &f))
+	assert.Equal(t, Float(3), f, "null is a no-op, like encoding/json")
+}
+
+func TestQuoteNonFinite(t *testing.T) {
+	tests := map[string]string{
+		`{"a": NaN, "b": [Infinity,-Infinity]}`: `{"a": "NaN", "b": ["Infinity","-Infinity"]}`,
+		`{"NaN": "x NaN \" Infinity"}`:          `{"NaN": "x NaN \" Infinity"}`,
+		`[1.5, NaNx]`:                           `[1.5, NaNx]`,
+		`NaN`:                                   `"NaN"`,
+	}
+	for input, exp := range tests {
+		assert.Equal(t, exp, string(QuoteNonFinite([]byte(input))), input)
+	}
+
+	var decoded struct {
+		A Float   `json:"a"`
+		B []Float `json:"b"`
+	}
+	assert.NoError(t, json.Unmarshal(QuoteNonFinite([]byte(`{"a": NaN, "b": [Infinity, 1]}`)), &decoded), "python's output decodes")
+	assert.True(t, math.IsNaN(float64(decoded.A)))
+	assert.Equal(t, []Float{Float(math.Inf(1)), 1}, decoded.B)
+}
+
+func TestCollections(t *testing.T) {
+	values := []float64{1, math.Inf(1)}
+	wrapped := Wrap[Float](values)
+	out, err := json.Marshal(wrapped)
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,"+Inf"]`, string(out))
+	assert.Equal(t, values, Unwrap[float64](wrapped))
+	assert.Nil(t, Wrap[Float32]([]float32(nil)))
+
+	byName := map[string]float64{"a": math.NaN()}
+	out, err = json.Marshal(WrapMap[NullableFloat](byName))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":null}`, string(out))
+	assert.True(t, math.IsNaN(UnwrapMap[float64](WrapMap[Float](byName))["a"]))
+}
+
+func FuzzFloatRoundTrip(f *testing.F) {
+	for _, seed := range []float64{0, -0.0, 1.5, math.MaxFloat64, math.SmallestNonzeroFloat64, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, value float64) {
+		out, err := json.Marshal(Float(value))
+		require.NoError(t, err)
+		var decoded Float
+		require.NoError(t, json.Unmarshal(out, &decoded))
+		test.NaNEqual(t, value, float64(decoded))
+
+		out, err = json.Marshal(Float32(value))
+		require.NoError(t, err)
+		var decoded32 Float32
+		require.NoError(t, json.Unmarshal(out, &decoded32))
+		test.NaNEqual(t, float64(float32(value)), float64(decoded32))
+
+		out, err = json.Marshal(NullableFloat(value))
+		require.NoError(t, err)
+		var nullable NullableFloat
+		require.NoError(t, json.Unmarshal(out, &nullable))
+		test.NaNEqual(t, value, float64(nullable))
+	})
+}
+
+func FuzzUnmarshal(f *testing.F) {
+	for _, seed := range []string{`1`, `"NaN"`, `NaN`, `-Infinity`, `null`, `"1"`, `{}`} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		var f Float
+		if f.UnmarshalJSON([]byte(input)) != nil {
+			return
+		}
+		out, err := f.MarshalJSON() // anything accepted must be written back as valid JSON
+		require.NoError(t, err)
+		require.True(t, json.Valid(out), string(out))
+	})
+}
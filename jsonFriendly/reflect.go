@@ -0,0 +1,915 @@
+package jsonFriendly
+
+import (
+	"bytes"
+	"cmp"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Marshal is json.Marshal that writes every float64 and float32 in v like Float and Float32 do, including inside
+// maps, slices, pointers, interfaces and embedded structs, so NaN and Inf never fail. json tags are honoured as usual,
+// and floats tagged ,string are quoted unless they are not finite.
+//
+// Types with their own MarshalJSON or MarshalText, and types that cannot hold a float, are left to encoding/json.
+func Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encode(&buf, reflect.ValueOf(v), 0); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal is json.Unmarshal that reads every float64 and float32 in v like Float and Float32 do, accepting NaN and
+// Inf in any form they do, including the bare NaN and Infinity Python writes.
+// Values decoded into interfaces are left as encoding/json decodes them. As with encoding/json, values of the wrong
+// type are skipped, and the first is returned as a *json.UnmarshalTypeError naming its field once the rest is decoded.
+func Unmarshal(data []byte, v any) error {
+	data = bytes.TrimSpace(QuoteNonFinite(data))
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() || !json.Valid(data) {
+		return json.Unmarshal(data, v) // for the same error as encoding/json
+	}
+	var d decodeState
+	if err := decoderFor(value.Type().Elem())(&d, data, value.Elem()); err != nil {
+		return err
+	}
+	return d.err
+}
+
+// maxDepth is how deep values may nest before they are assumed to hold a cycle, which encoding/json also refuses
+const maxDepth = 1000
+
+type encoderFunc func(buf *bytes.Buffer, v reflect.Value, depth int) error
+
+// decoderFunc decodes one JSON value into v, which must be addressable. Values of the wrong type are recorded in d and
+// skipped, so only other errors are returned
+type decoderFunc func(d *decodeState, data []byte, v reflect.Value) error
+
+var (
+	marshalerTypes   = []reflect.Type{reflect.TypeFor[json.Marshaler](), reflect.TypeFor[encoding.TextMarshaler]()}
+	unmarshalerTypes = []reflect.Type{reflect.TypeFor[json.Unmarshaler](), reflect.TypeFor[encoding.TextUnmarshaler]()}
+
+	encoders     sync.Map // reflect.Type to encoderFunc
+	decoders     sync.Map // reflect.Type to decoderFunc
+	structFields sync.Map // reflect.Type to []field
+)
+
+func encode(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	if !v.IsValid() {
+		buf.WriteString("null")
+		return nil
+	}
+	return encoderFor(v.Type())(buf, v, depth)
+}
+
+func encoderFor(t reflect.Type) encoderFunc {
+	if cached, ok := encoders.Load(t); ok {
+		return cached.(encoderFunc)
+	}
+	// recursive types reach t again while it is being built, so they get a func that waits for the finished one
+	var (
+		wait  sync.WaitGroup
+		built encoderFunc
+	)
+	wait.Add(1)
+	cached, loaded := encoders.LoadOrStore(t, encoderFunc(func(buf *bytes.Buffer, v reflect.Value, depth int) error {
+		wait.Wait()
+		return built(buf, v, depth)
+	}))
+	if loaded {
+		return cached.(encoderFunc)
+	}
+	built = newEncoder(t)
+	wait.Done()
+	encoders.Store(t, built)
+	return built
+}
+
+func newEncoder(t reflect.Type) encoderFunc {
+	if !holdsFloats(t, marshalerTypes, map[reflect.Type]bool{}) {
+		return encodeWithJson
+	}
+	switch t.Kind() {
+	case reflect.Float64, reflect.Float32:
+		return func(buf *bytes.Buffer, v reflect.Value, _ int) error {
+			buf.Write(marshalFloat(v.Float(), t.Bits(), DefaultEncoding))
+			return nil
+		}
+	case reflect.Interface:
+		return func(buf *bytes.Buffer, v reflect.Value, depth int) error {
+			return encode(buf, v.Elem(), depth+1)
+		}
+	case reflect.Pointer:
+		elem := encoderFor(t.Elem())
+		return func(buf *bytes.Buffer, v reflect.Value, depth int) error {
+			if v.IsNil() {
+				buf.WriteString("null")
+				return nil
+			}
+			if depth > maxDepth {
+				return tooDeep(t)
+			}
+			return elem(buf, v.Elem(), depth+1)
+		}
+	case reflect.Slice:
+		elem := encoderFor(t.Elem())
+		return func(buf *bytes.Buffer, v reflect.Value, depth int) error {
+			if v.IsNil() {
+				buf.WriteString("null")
+				return nil
+			}
+			return encodeItems(buf, v, elem, depth)
+		}
+	case reflect.Array:
+		elem := encoderFor(t.Elem())
+		return func(buf *bytes.Buffer, v reflect.Value, depth int) error {
+			return encodeItems(buf, v, elem, depth)
+		}
+	case reflect.Map:
+		return newMapEncoder(t)
+	case reflect.Struct:
+		return newStructEncoder(t)
+	}
+	return encodeWithJson
+}
+
+// encodeWithJson leaves v to encoding/json, through its address when it has one, so pointer methods are used as
+// encoding/json would use them
+func encodeWithJson(buf *bytes.Buffer, v reflect.Value, _ int) error {
+	if v.CanAddr() {
+		v = v.Addr()
+	}
+	out, err := json.Marshal(v.Interface())
+	buf.Write(out)
+	return err
+}
+
+// tooDeep is returned for values nesting deeper than maxDepth through pointers, slices or maps, the types that can
+// hold cycles
+func tooDeep(t reflect.Type) error {
+	return fmt.Errorf("jsonFriendly: %s nests too deeply, it may hold a cycle", t)
+}
+
+func encodeItems(buf *bytes.Buffer, v reflect.Value, elem encoderFunc, depth int) error {
+	if depth > maxDepth {
+		return tooDeep(v.Type())
+	}
+	buf.WriteByte('[')
+	for i := range v.Len() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := elem(buf, v.Index(i), depth+1); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+type mapEntry struct {
+	key   string
+	value reflect.Value
+}
+
+// newMapEncoder writes maps with their keys sorted and resolved as encoding/json does
+func newMapEncoder(t reflect.Type) encoderFunc {
+	elem := encoderFor(t.Elem())
+	return func(buf *bytes.Buffer, v reflect.Value, depth int) error {
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		if depth > maxDepth {
+			return tooDeep(t)
+		}
+		entries := make([]mapEntry, 0, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			key, err := mapKeyString(iter.Key())
+			if err != nil {
+				return err
+			}
+			entries = append(entries, mapEntry{key: key, value: iter.Value()})
+		}
+		slices.SortFunc(entries, func(a, b mapEntry) int {
+			return strings.Compare(a.key, b.key)
+		})
+		buf.WriteByte('{')
+		for i, entry := range entries {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeString(buf, entry.key)
+			buf.WriteByte(':')
+			if err := elem(buf, entry.value, depth+1); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	}
+}
+
+func mapKeyString(key reflect.Value) (string, error) {
+	if key.Kind() == reflect.String {
+		return key.String(), nil
+	}
+	if marshaler, ok := key.Interface().(encoding.TextMarshaler); ok {
+		if key.Kind() == reflect.Pointer && key.IsNil() {
+			return "", nil
+		}
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), nil
+	case reflect.Float64, reflect.Float32:
+		return strconv.FormatFloat(key.Float(), 'g', -1, key.Type().Bits()), nil
+	}
+	return "", &json.UnsupportedTypeError{Type: key.Type()}
+}
+
+func newStructEncoder(t reflect.Type) encoderFunc {
+	fields := fieldsOf(t)
+	encoders := make([]encoderFunc, len(fields))
+	for i, f := range fields {
+		encoders[i] = encoderFor(f.typ)
+		if f.quoted && !hasMethods(f.typ, marshalerTypes) {
+			encoders[i] = encodeQuoted
+		}
+	}
+	return func(buf *bytes.Buffer, v reflect.Value, depth int) error {
+		buf.WriteByte('{')
+		first := true
+		for i, f := range fields {
+			value, ok := fieldValue(v, f.index)
+			if !ok || f.omitEmpty && isEmptyValue(value) || f.omitZero && isZero(value) {
+				continue
+			}
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+			buf.Write(f.key)
+			if err := encoders[i](buf, value, depth+1); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	}
+}
+
+// encodeQuoted writes v as the ,string option does, except for non-finite floats, which are written as
+// DefaultEncoding says, as they are not numbers
+func encodeQuoted(buf *bytes.Buffer, v reflect.Value, _ int) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Float64, reflect.Float32:
+		if math.IsNaN(v.Float()) || math.IsInf(v.Float(), 0) {
+			buf.Write(marshalFloat(v.Float(), v.Type().Bits(), DefaultEncoding))
+			return nil
+		}
+		buf.WriteByte('"')
+		buf.Write(marshalFloat(v.Float(), v.Type().Bits(), DefaultEncoding))
+		buf.WriteByte('"')
+		return nil
+	case reflect.String:
+		quoted, err := json.Marshal(v.String())
+		writeString(buf, string(quoted))
+		return err
+	}
+	out, err := json.Marshal(v.Interface())
+	buf.WriteByte('"')
+	buf.Write(out)
+	buf.WriteByte('"')
+	return err
+}
+
+func writeString(buf *bytes.Buffer, s string) {
+	out, _ := json.Marshal(s) // strings always marshal
+	buf.Write(out)
+}
+
+// fieldValue follows index through embedded pointers, returning false if one of them is nil
+func fieldValue(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Float32, reflect.Float64, reflect.Interface, reflect.Pointer,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.IsZero()
+	}
+	return false
+}
+
+// isZero is the omitzero check, which prefers an IsZero method
+func isZero(v reflect.Value) bool {
+	type zeroer interface{ IsZero() bool }
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return true
+	}
+	if z, ok := v.Interface().(zeroer); ok {
+		return z.IsZero()
+	}
+	if v.CanAddr() {
+		if z, ok := v.Addr().Interface().(zeroer); ok {
+			return z.IsZero()
+		}
+	}
+	return v.IsZero()
+}
+
+// decodeState tracks where decoding is, to report type errors as encoding/json does
+type decodeState struct {
+	path       []string // the fields, keys and indexes leading to the current value
+	structName string   // the innermost struct being decoded
+	err        error    // the first type error, returned once the rest is decoded
+}
+
+// typeError records that data could not be decoded into t, so the value is skipped as encoding/json skips it
+func (d *decodeState) typeError(data []byte, t reflect.Type) {
+	d.saveError(&json.UnmarshalTypeError{Value: describe(data), Type: t})
+}
+
+// saveError keeps err if it is the first, filling in where a type error happened
+func (d *decodeState) saveError(err error) {
+	if d.err != nil {
+		return
+	}
+	if typeErr, ok := err.(*json.UnmarshalTypeError); ok {
+		copied := *typeErr
+		copied.Field = strings.Join(d.path, ".")
+		if typeErr.Field != "" { // encoding/json's path within the value it decoded
+			copied.Field = strings.TrimPrefix(copied.Field+"."+typeErr.Field, ".")
+		}
+		copied.Struct = cmp.Or(typeErr.Struct, d.structName)
+		err = &copied
+	}
+	d.err = err
+}
+
+// at decodes data into v under name, a field, key or index
+func (d *decodeState) at(name string, decode decoderFunc, data []byte, v reflect.Value) error {
+	d.path = append(d.path, name)
+	err := decode(d, data, v)
+	d.path = d.path[:len(d.path)-1]
+	return err
+}
+
+func decoderFor(t reflect.Type) decoderFunc {
+	if cached, ok := decoders.Load(t); ok {
+		return cached.(decoderFunc)
+	}
+	var (
+		wait  sync.WaitGroup
+		built decoderFunc
+	)
+	wait.Add(1)
+	cached, loaded := decoders.LoadOrStore(t, decoderFunc(func(d *decodeState, data []byte, v reflect.Value) error {
+		wait.Wait()
+		return built(d, data, v)
+	}))
+	if loaded {
+		return cached.(decoderFunc)
+	}
+	built = newDecoder(t)
+	wait.Done()
+	decoders.Store(t, built)
+	return built
+}
+
+func newDecoder(t reflect.Type) decoderFunc {
+	if !holdsFloats(t, unmarshalerTypes, map[reflect.Type]bool{}) {
+		return decodeWithJson
+	}
+	switch t.Kind() {
+	case reflect.Float64, reflect.Float32:
+		return func(d *decodeState, data []byte, v reflect.Value) error {
+			val, err := unmarshalFloat(data, t.Bits(), DefaultEncoding)
+			if err != nil {
+				d.typeError(data, t)
+				return nil
+			}
+			if val != nil {
+				v.SetFloat(*val)
+			}
+			return nil
+		}
+	case reflect.Interface:
+		return func(d *decodeState, data []byte, v reflect.Value) error {
+			// decode into the pointer the interface already holds, as encoding/json does
+			if elem := v.Elem(); elem.Kind() == reflect.Pointer && !elem.IsNil() && !isNull(data) {
+				return decoderFor(elem.Type().Elem())(d, data, elem.Elem())
+			}
+			return decodeWithJson(d, data, v)
+		}
+	case reflect.Pointer:
+		elem := decoderFor(t.Elem())
+		return func(d *decodeState, data []byte, v reflect.Value) error {
+			if isNull(data) {
+				v.SetZero()
+				return nil
+			}
+			if v.IsNil() {
+				v.Set(reflect.New(t.Elem()))
+			}
+			return elem(d, data, v.Elem())
+		}
+	case reflect.Slice:
+		elem := decoderFor(t.Elem())
+		return func(d *decodeState, data []byte, v reflect.Value) error {
+			if isNull(data) {
+				v.SetZero()
+				return nil
+			}
+			if data[0] != '[' {
+				d.typeError(data, t)
+				return nil
+			}
+			items := splitArray(data)
+			slice := reflect.MakeSlice(t, len(items), len(items))
+			for i, item := range items {
+				if err := d.at(strconv.Itoa(i), elem, item, slice.Index(i)); err != nil {
+					return err
+				}
+			}
+			v.Set(slice)
+			return nil
+		}
+	case reflect.Array:
+		elem := decoderFor(t.Elem())
+		return func(d *decodeState, data []byte, v reflect.Value) error {
+			if isNull(data) {
+				return nil
+			}
+			if data[0] != '[' {
+				d.typeError(data, t)
+				return nil
+			}
+			items := splitArray(data)
+			for i := range v.Len() {
+				if i >= len(items) {
+					v.Index(i).SetZero() // as encoding/json does for short arrays
+					continue
+				}
+				if err := d.at(strconv.Itoa(i), elem, items[i], v.Index(i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case reflect.Map:
+		elem := decoderFor(t.Elem())
+		return func(d *decodeState, data []byte, v reflect.Value) error {
+			if isNull(data) {
+				v.SetZero()
+				return nil
+			}
+			if data[0] != '{' {
+				d.typeError(data, t)
+				return nil
+			}
+			if v.IsNil() {
+				v.Set(reflect.MakeMap(t))
+			}
+			return eachMember(data, func(name string, item []byte) error {
+				key, err := mapKeyOf(name, t.Key())
+				if typeErr, ok := err.(*json.UnmarshalTypeError); ok {
+					d.saveError(typeErr) // the entry is skipped, as encoding/json skips it
+					return nil
+				} else if err != nil {
+					return err
+				}
+				value := reflect.New(t.Elem()).Elem()
+				if err := d.at(name, elem, item, value); err != nil {
+					return err
+				}
+				v.SetMapIndex(key, value)
+				return nil
+			})
+		}
+	case reflect.Struct:
+		return newStructDecoder(t)
+	}
+	return decodeWithJson
+}
+
+// splitArray returns the elements of the JSON array data, which must be valid as Unmarshal checks it up front
+func splitArray(data []byte) [][]byte {
+	var items [][]byte
+	for i := skipSpace(data, 1); data[i] != ']'; {
+		end := valueEnd(data, i)
+		items = append(items, data[i:end])
+		i = skipSpace(data, end)
+		if data[i] == ',' {
+			i = skipSpace(data, i+1)
+		}
+	}
+	return items
+}
+
+// eachMember calls f with the members of the JSON object data in order, which must be valid as Unmarshal checks it
+// up front
+func eachMember(data []byte, f func(name string, value []byte) error) error {
+	for i := skipSpace(data, 1); data[i] != '}'; {
+		end := valueEnd(data, i)
+		name := string(data[i+1 : end-1])
+		if bytes.IndexByte(data[i:end], '\\') >= 0 {
+			_ = json.Unmarshal(data[i:end], &name)
+		}
+		i = skipSpace(data, skipSpace(data, end)+1) // past the colon
+		end = valueEnd(data, i)
+		if err := f(name, data[i:end]); err != nil {
+			return err
+		}
+		i = skipSpace(data, end)
+		if data[i] == ',' {
+			i = skipSpace(data, i+1)
+		}
+	}
+	return nil
+}
+
+func skipSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+// valueEnd returns the end of the valid JSON value starting at data[i]
+func valueEnd(data []byte, i int) int {
+	depth, inString := 0, false
+	for ; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString && c == '\\':
+			i++
+		case inString:
+			inString = c != '"'
+			if !inString && depth == 0 {
+				return i + 1
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			if depth == 0 {
+				return i // the end of a number or literal, which is also the end of its container
+			}
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case depth == 0 && (c == ',' || c == ' ' || c == '\t' || c == '\n' || c == '\r'):
+			return i
+		}
+	}
+	return i
+}
+
+// decodeWithJson leaves v to encoding/json, keeping its type errors to return once the rest is decoded
+func decodeWithJson(d *decodeState, data []byte, v reflect.Value) error {
+	err := json.Unmarshal(data, v.Addr().Interface())
+	if _, ok := err.(*json.UnmarshalTypeError); ok {
+		d.saveError(err)
+		return nil
+	}
+	return err
+}
+
+func isNull(data []byte) bool {
+	return string(data) == "null"
+}
+
+// describe names the JSON value data as encoding/json does in an UnmarshalTypeError
+func describe(data []byte) string {
+	kinds := map[byte]string{'{': "object", '[': "array", '"': "string", 't': "bool", 'f': "bool"}
+	return cmp.Or(kinds[data[0]], "number "+string(data))
+}
+
+func mapKeyOf(name string, t reflect.Type) (reflect.Value, error) {
+	key := reflect.New(t)
+	if unmarshaler, ok := key.Interface().(encoding.TextUnmarshaler); ok {
+		return key.Elem(), unmarshaler.UnmarshalText([]byte(name))
+	}
+	switch t.Kind() {
+	case reflect.String:
+		key.Elem().SetString(name)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(name, 10, t.Bits())
+		if err != nil {
+			return key, &json.UnmarshalTypeError{Value: "number " + name, Type: t}
+		}
+		key.Elem().SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(name, 10, t.Bits())
+		if err != nil {
+			return key, &json.UnmarshalTypeError{Value: "number " + name, Type: t}
+		}
+		key.Elem().SetUint(n)
+	case reflect.Float64, reflect.Float32:
+		n, err := strconv.ParseFloat(name, t.Bits())
+		if err != nil {
+			return key, &json.UnmarshalTypeError{Value: "number " + name, Type: t}
+		}
+		key.Elem().SetFloat(n)
+	default:
+		return key, &json.UnmarshalTypeError{Value: "object", Type: t}
+	}
+	return key.Elem(), nil
+}
+
+// newStructDecoder matches object keys to fields exactly, then ignoring case, as encoding/json does
+func newStructDecoder(t reflect.Type) decoderFunc {
+	fields := fieldsOf(t)
+	byName := make(map[string]int, len(fields))
+	decoders := make([]decoderFunc, len(fields))
+	for i, f := range fields {
+		byName[f.name] = i
+		decoders[i] = decoderFor(f.typ)
+		if f.quoted && !hasMethods(f.typ, unmarshalerTypes) {
+			decoders[i] = quotedDecoder(decoders[i], f.typ)
+		}
+	}
+	return func(d *decodeState, data []byte, v reflect.Value) error {
+		if isNull(data) {
+			return nil
+		}
+		if data[0] != '{' {
+			d.typeError(data, t)
+			return nil
+		}
+		outer := d.structName
+		d.structName = t.Name()
+		defer func() { d.structName = outer }()
+		return eachMember(data, func(name string, item []byte) error {
+			i, ok := byName[name]
+			if !ok {
+				i = slices.IndexFunc(fields, func(f field) bool { return strings.EqualFold(f.name, name) })
+			}
+			if i < 0 {
+				return nil
+			}
+			value, err := settableField(v, fields[i].index)
+			if err != nil {
+				return err
+			}
+			return d.at(fields[i].name, decoders[i], item, value)
+		})
+	}
+}
+
+// quotedDecoder reads values written with the ,string option, and the unquoted non-finite floats encodeQuoted writes
+func quotedDecoder(decode decoderFunc, t reflect.Type) decoderFunc {
+	elem := t
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	isFloat := elem.Kind() == reflect.Float64 || elem.Kind() == reflect.Float32
+	return func(d *decodeState, data []byte, v reflect.Value) error {
+		if isNull(data) {
+			return decode(d, data, v)
+		}
+		if isFloat {
+			if _, err := unmarshalFloat(data, elem.Bits(), DefaultEncoding); err == nil {
+				return decode(d, data, v)
+			}
+		}
+		var inner string
+		if err := json.Unmarshal(data, &inner); err != nil {
+			return fmt.Errorf("json: invalid use of ,string struct tag, trying to unmarshal %s into %v", data, t)
+		}
+		return decode(d, []byte(inner), v)
+	}
+}
+
+// settableField follows index, allocating nil embedded pointers as encoding/json does
+func settableField(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("json: cannot set embedded pointer to unexported struct: %v", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+func hasMethods(t reflect.Type, methods []reflect.Type) bool {
+	for _, method := range methods {
+		if t.Implements(method) || reflect.PointerTo(t).Implements(method) {
+			return true
+		}
+	}
+	return false
+}
+
+// holdsFloats reports whether values of t can hold floats this package writes or reads itself.
+// Types that cannot are left to encoding/json whole, as are types with the given methods
+func holdsFloats(t reflect.Type, methods []reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] || hasMethods(t, methods) {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Float64, reflect.Float32, reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return holdsFloats(t.Elem(), methods, seen)
+	case reflect.Struct:
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if (field.IsExported() || field.Anonymous) && holdsFloats(field.Type, methods, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// field is a struct field as encoding/json sees it, possibly promoted from an embedded struct
+type field struct {
+	name      string
+	key       []byte // the quoted name and a colon
+	tagged    bool
+	index     []int
+	typ       reflect.Type
+	omitEmpty bool
+	omitZero  bool
+	quoted    bool
+}
+
+// fieldsOf lists the fields encoding/json reads and writes for the struct type t, in its order, following its rules
+// for tags and for fields promoted from embedded structs
+func fieldsOf(t reflect.Type) []field {
+	if cached, ok := structFields.Load(t); ok {
+		return cached.([]field)
+	}
+	var fields []field
+	var current []field
+	next := []field{{typ: t}}
+	count, nextCount := map[reflect.Type]int{}, map[reflect.Type]int{}
+	visited := map[reflect.Type]bool{}
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[reflect.Type]int{}
+		for _, embedded := range current {
+			if visited[embedded.typ] {
+				continue
+			}
+			visited[embedded.typ] = true
+			for i := range embedded.typ.NumField() {
+				sf := embedded.typ.Field(i)
+				if sf.Anonymous {
+					ft := sf.Type
+					if ft.Kind() == reflect.Pointer {
+						ft = ft.Elem()
+					}
+					if !sf.IsExported() && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, options, _ := strings.Cut(tag, ",")
+				if !isValidTag(name) {
+					name = ""
+				}
+				index := append(slices.Clone(embedded.index), i)
+				ft := sf.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+
+				if name != "" || !sf.Anonymous || ft.Kind() != reflect.Struct {
+					optionList := strings.Split(options, ",")
+					fields = append(fields, field{
+						name:      cmp.Or(name, sf.Name),
+						tagged:    name != "",
+						index:     index,
+						typ:       sf.Type,
+						omitEmpty: slices.Contains(optionList, "omitempty"),
+						omitZero:  slices.Contains(optionList, "omitzero"),
+						quoted:    slices.Contains(optionList, "string") && isQuotable(ft.Kind()),
+					})
+					if count[embedded.typ] > 1 {
+						// the struct is embedded more than once at this depth, so its fields annihilate each other below
+						fields = append(fields, fields[len(fields)-1])
+					}
+					continue
+				}
+				nextCount[ft]++
+				if nextCount[ft] == 1 {
+					next = append(next, field{name: ft.Name(), index: index, typ: ft})
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(fields, func(a, b field) int {
+		return cmp.Or(
+			strings.Compare(a.name, b.name),
+			cmp.Compare(len(a.index), len(b.index)),
+			cmp.Compare(untagged(a), untagged(b)),
+			slices.Compare(a.index, b.index),
+		)
+	})
+	// of the fields sharing a name, the shallowest wins, then the tagged one. Any other tie hides them all
+	var dominant []field
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+		if j-i == 1 || len(fields[i].index) < len(fields[i+1].index) || fields[i].tagged && !fields[i+1].tagged {
+			dominant = append(dominant, fields[i])
+		}
+		i = j
+	}
+	slices.SortFunc(dominant, func(a, b field) int {
+		return slices.Compare(a.index, b.index)
+	})
+	for i := range dominant {
+		key, _ := json.Marshal(dominant[i].name)
+		dominant[i].key = append(key, ':')
+	}
+
+	cached, _ := structFields.LoadOrStore(t, dominant)
+	return cached.([]field)
+}
+
+func untagged(f field) int {
+	if f.tagged {
+		return 0
+	}
+	return 1
+}
+
+func isQuotable(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// isValidTag is encoding/json's check for names it accepts in tags
+func isValidTag(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,324 @@
+package jsonFriendly
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Inner struct {
+	Value float64 `json:"value"`
+}
+
+type hidden struct {
+	Depth float32 `json:"depth"`
+}
+
+func (hidden) String() string { return "methods on embedded types are dropped from the mirror" }
+
+type celsius float64
+
+type Celsius float64
+
+type reading struct {
+	Celsius
+	X float64
+}
+
+type sample struct {
+	Inner
+	*hidden
+	Name     string             `json:"name"`
+	Score    float64            `json:"score"`
+	Small    float32            `json:"small,omitempty"`
+	Skipped  float64            `json:"-"`
+	Quoted   float64            `json:"quoted,string"`
+	Temp     celsius            `json:"temp"`
+	Scores   []float64          `json:"scores"`
+	ByName   map[string]float64 `json:"byName"`
+	Pair     [2]float64         `json:"pair"`
+	Optional *float64           `json:"optional"`
+	Any      any                `json:"any"`
+	When     time.Time          `json:"when"`
+	friendly Float
+	count    int
+}
+
+type node struct {
+	Value    float64 `json:"value"`
+	Children []node  `json:"children"`
+}
+
+type tree struct {
+	V    float64
+	Kids []*tree
+}
+
+func TestMarshal(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	v := sample{
+		Inner:    Inner{Value: inf},
+		hidden:   &hidden{Depth: float32(math.Inf(-1))},
+		Name:     "x",
+		Score:    nan,
+		Skipped:  nan,
+		Quoted:   1.5,
+		Temp:     celsius(nan),
+		Scores:   []float64{1, nan},
+		ByName:   map[string]float64{"a": inf},
+		Pair:     [2]float64{nan, 2},
+		Optional: &nan,
+		Any:      map[string]any{"nested": []any{nan, "NaN", 1.0}},
+		When:     time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+		count:    7,
+	}
+
+	out, err := Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"value": "+Inf",
+		"depth": "-Inf",
+		"name": "x",
+		"score": "NaN",
+		"quoted": "1.5",
+		"temp": "NaN",
+		"scores": [1, "NaN"],
+		"byName": {"a": "+Inf"},
+		"pair": ["NaN", 2],
+		"optional": "NaN",
+		"any": {"nested": ["NaN", "NaN", 1]},
+		"when": "2025-01-03T00:00:00Z"
+	}`, string(out))
+
+	t.Run("matches encoding/json for finite values", func(t *testing.T) {
+		finite := v
+		finite.Inner.Value, finite.hidden, finite.Score, finite.Temp, finite.Optional = 1, nil, 2, 3, nil
+		finite.Scores, finite.ByName, finite.Pair, finite.Any = []float64{4}, map[string]float64{"a": 5}, [2]float64{6, 7}, []any{8.5}
+		expected, err := json.Marshal(finite)
+		require.NoError(t, err)
+		actual, err := Marshal(finite)
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(actual), "including field order")
+	})
+	t.Run("other values", func(t *testing.T) {
+		for _, value := range []any{nil, nan, float32(inf), "text", []int{1}, &v.Score, Float(nan)} {
+			_, err := Marshal(value)
+			assert.NoError(t, err)
+		}
+		out, err := Marshal(nan)
+		assert.NoError(t, err)
+		assert.Equal(t, NaNFloatString, string(out))
+	})
+	t.Run("embedded named floats", func(t *testing.T) {
+		out, err := Marshal(reading{Celsius: Celsius(nan), X: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"Celsius":"NaN","X":1}`, string(out))
+	})
+	t.Run("recursive types", func(t *testing.T) {
+		out, err := Marshal(node{Value: nan, Children: []node{{Value: inf}}})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"value":"NaN","children":[{"value":"+Inf","children":null}]}`, string(out))
+
+		out, err = Marshal(tree{V: 1, Kids: []*tree{{V: nan}, nil}})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"V":1,"Kids":[{"V":"NaN","Kids":null},null]}`, string(out))
+
+		cycle := &tree{}
+		cycle.Kids = []*tree{cycle}
+		_, err = Marshal(cycle)
+		assert.Error(t, err)
+		cyclic := map[string]any{}
+		cyclic["self"] = cyclic
+		_, err = Marshal(cyclic)
+		assert.Error(t, err)
+	})
+	t.Run("string option", func(t *testing.T) {
+		quoted := struct {
+			A float64  `json:"a,string"`
+			B float64  `json:"b,string"`
+			C *float32 `json:"c,string"`
+			D int      `json:"d,string"`
+			E string   `json:"e,string"`
+		}{A: 1.5, B: nan, C: new(float32), D: 2, E: "x"}
+		*quoted.C = float32(math.Inf(-1))
+		out, err := Marshal(quoted)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"a":"1.5","b":"NaN","c":"-Inf","d":"2","e":"\"x\""}`, string(out))
+	})
+}
+
+func TestUnmarshal(t *testing.T) {
+	data := []byte(`{
+		"value": Infinity,
+		"depth": "-Inf",
+		"score": NaN,
+		"small": 1.5,
+		"quoted": "2.5",
+		"temp": "NaN",
+		"scores": [1, "+Infinity", -Infinity],
+		"byName": {"a": "NaN"},
+		"pair": [NaN, 2],
+		"optional": "-Inf",
+		"any": {"x": 1}
+	}`)
+	var v sample
+	v.count = 3
+	v.hidden = &hidden{} // like encoding/json, it cannot allocate embedded pointers to unexported structs
+	require.NoError(t, Unmarshal(data, &v))
+
+	assert.True(t, math.IsInf(v.Value, 1))
+	require.NotNil(t, v.hidden)
+	assert.True(t, math.IsInf(float64(v.hidden.Depth), -1))
+	assert.True(t, math.IsNaN(v.Score))
+	assert.Equal(t, float32(1.5), v.Small)
+	assert.Equal(t, 2.5, v.Quoted)
+	assert.True(t, math.IsNaN(float64(v.Temp)))
+	assert.Equal(t, []float64{1, math.Inf(1), math.Inf(-1)}, v.Scores)
+	assert.True(t, math.IsNaN(v.ByName["a"]))
+	assert.True(t, math.IsNaN(v.Pair[0]))
+	require.NotNil(t, v.Optional)
+	assert.True(t, math.IsInf(*v.Optional, -1))
+	assert.Equal(t, map[string]any{"x": 1.0}, v.Any)
+	assert.Equal(t, 3, v.count, "unexported fields are untouched")
+
+	t.Run("errors like encoding/json", func(t *testing.T) {
+		var notPointer sample
+		assert.Error(t, Unmarshal(data, notPointer))
+		assert.Error(t, Unmarshal([]byte(`{"score": "abc"}`), &v))
+		assert.Error(t, Unmarshal([]byte(`{`), &v))
+		assert.Error(t, Unmarshal([]byte(`{"depth": 1}`), &sample{}), "embedded pointer to an unexported struct")
+		assert.Error(t, Unmarshal([]byte(`{"scores": {}}`), &v))
+	})
+	t.Run("type errors name the field and the rest is decoded", func(t *testing.T) {
+		var decoded sample
+		err := Unmarshal([]byte(`{"scores": [1, "x", 3], "byName": {"a": true}, "name": "y"}`), &decoded)
+		var typeErr *json.UnmarshalTypeError
+		require.ErrorAs(t, err, &typeErr)
+		assert.Equal(t, "scores.1", typeErr.Field, "the first bad value")
+		assert.Equal(t, "string", typeErr.Value)
+		assert.Equal(t, "sample", typeErr.Struct)
+		assert.Equal(t, []float64{1, 0, 3}, decoded.Scores)
+		assert.Equal(t, "y", decoded.Name)
+
+		var nested struct {
+			Inner []struct {
+				Count int
+				Value float64
+			}
+		}
+		err = Unmarshal([]byte(`{"Inner": [{"Count": "x", "Value": NaN}]}`), &nested)
+		require.ErrorAs(t, err, &typeErr)
+		assert.Equal(t, "Inner.0.Count", typeErr.Field, "including inside values left to encoding/json")
+		assert.True(t, math.IsNaN(nested.Inner[0].Value))
+	})
+	t.Run("float map keys", func(t *testing.T) {
+		values := map[float64]float64{1.5: math.NaN(), 1e21: 2}
+		out, err := Marshal(values)
+		require.NoError(t, err)
+		assert.Equal(t, `{"1.5":"NaN","1e+21":2}`, string(out))
+		expected, err := json.Marshal(map[float64]int{1.5: 0, 1e21: 2})
+		require.NoError(t, err)
+		assert.Equal(t, `{"1.5":0,"1e+21":2}`, string(expected), "keys are written as encoding/json writes them")
+
+		var decoded map[float32]float64
+		require.NoError(t, Unmarshal(out, &decoded))
+		assert.True(t, math.IsNaN(decoded[1.5]))
+		assert.Equal(t, 2.0, decoded[1e21])
+	})
+	t.Run("embedded named floats", func(t *testing.T) {
+		var r reading
+		require.NoError(t, Unmarshal([]byte(`{"Celsius": NaN, "x": 2}`), &r))
+		assert.True(t, math.IsNaN(float64(r.Celsius)))
+		assert.Equal(t, 2.0, r.X)
+	})
+	t.Run("recursive types", func(t *testing.T) {
+		var decoded tree
+		require.NoError(t, Unmarshal([]byte(`{"V": 1, "Kids": [{"V": "NaN", "Kids": [{"V": "-Inf"}]}, null]}`), &decoded))
+		require.Len(t, decoded.Kids, 2)
+		assert.True(t, math.IsNaN(decoded.Kids[0].V))
+		assert.True(t, math.IsInf(decoded.Kids[0].Kids[0].V, -1))
+		assert.Nil(t, decoded.Kids[1])
+	})
+	t.Run("string option", func(t *testing.T) {
+		var quoted struct {
+			A float64  `json:"a,string"`
+			B float64  `json:"b,string"`
+			C *float32 `json:"c,string"`
+			D int      `json:"d,string"`
+			E string   `json:"e,string"`
+		}
+		require.NoError(t, Unmarshal([]byte(`{"a":"1.5","b":"NaN","c":"-Inf","d":"2","e":"\"x\""}`), &quoted))
+		assert.Equal(t, 1.5, quoted.A)
+		assert.True(t, math.IsNaN(quoted.B))
+		require.NotNil(t, quoted.C)
+		assert.True(t, math.IsInf(float64(*quoted.C), -1))
+		assert.Equal(t, 2, quoted.D)
+		assert.Equal(t, "x", quoted.E)
+		assert.Error(t, Unmarshal([]byte(`{"d": 2}`), &quoted))
+	})
+	t.Run("round trip", func(t *testing.T) {
+		values := map[string][]float64{"a": {math.NaN(), math.Inf(-1), 0.1}}
+		out, err := Marshal(values)
+		require.NoError(t, err)
+		var decoded map[string][]float64
+		require.NoError(t, Unmarshal(out, &decoded))
+		assert.True(t, math.IsNaN(decoded["a"][0]))
+		assert.Equal(t, values["a"][1:], decoded["a"][1:])
+	})
+}
+
+type benchmarkRecord struct {
+	Id      string             `json:"id"`
+	Score   float64            `json:"score"`
+	Weights []float64          `json:"weights"`
+	Stats   map[string]float64 `json:"stats"`
+	Inner   Inner              `json:"inner"`
+}
+
+func benchmarkRecords() []benchmarkRecord {
+	records := make([]benchmarkRecord, 100)
+	for i := range records {
+		records[i] = benchmarkRecord{
+			Id:      "record",
+			Score:   float64(i) / 3,
+			Weights: []float64{1.5, 2.5, 3.5, 4.5},
+			Stats:   map[string]float64{"min": 0.1, "max": 9.9},
+			Inner:   Inner{Value: 42},
+		}
+	}
+	return records
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	records := benchmarkRecords()
+	b.Run("encoding/json", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = json.Marshal(records)
+		}
+	})
+	b.Run("jsonFriendly", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = Marshal(records)
+		}
+	})
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	data, _ := json.Marshal(benchmarkRecords())
+	b.Run("encoding/json", func(b *testing.B) {
+		for b.Loop() {
+			var records []benchmarkRecord
+			_ = json.Unmarshal(data, &records)
+		}
+	})
+	b.Run("jsonFriendly", func(b *testing.B) {
+		for b.Loop() {
+			var records []benchmarkRecord
+			_ = Unmarshal(data, &records)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"go.elastic.co/ecszap"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Encoding is how log entries are written
+type Encoding string
+
+const (
+	EncodingECS     Encoding = "ecs"     // ECS JSON, for shipping to Elastic
+	EncodingJSON    Encoding = "json"    // plain zap JSON
+	EncodingConsole Encoding = "console" // human readable lines, for local runs
+)
+
+// SamplingConfig keeps the first Initial entries with the same level and message per Tick, then every Thereafter-th
+type SamplingConfig struct {
+	Initial    int
+	Thereafter int
+	Tick       time.Duration // defaults to 1 second
+}
+
+type LoggerConfig struct {
+	Level      AtomicLevel      // shared by every logger built from the config, so changing it changes them all. Defaults to DefaultLevel()
+	Sinks      []io.Writer      // every entry is written to each sink. Defaults to stdout
+	Encoding   Encoding         // defaults to EncodingECS
+	Sampling   *SamplingConfig  // nil logs every entry
+	RateLimit  *RateLimitConfig // nil logs every entry, unlike Sampling it reports how many entries were dropped
+	CallerSkip int              // extra frames to skip when reporting the caller, for loggers called through wrappers
+	Fields     []zap.Field      // added to every entry
+}
+
+func DefaultLoggerConfig() LoggerConfig {
+	return LoggerConfig{
+		Level:    DefaultLevel(),
+		Sinks:    []io.Writer{os.Stdout},
+		Encoding: EncodingECS,
+	}
+}
+
+type loggerConfigKey struct{}
+
+// WithLoggerConfig stores the config NewLogger builds loggers from
+func WithLoggerConfig(parent context.Context, config LoggerConfig) context.Context {
+	return context.WithValue(parent, loggerConfigKey{}, config)
+}
+
+// GetLoggerConfig returns the config stored by WithLoggerConfig, or DefaultLoggerConfig()
+func GetLoggerConfig(ctx context.Context) LoggerConfig {
+	if config, ok := ctx.Value(loggerConfigKey{}).(LoggerConfig); ok {
+		return config
+	}
+	return DefaultLoggerConfig()
+}
+
+// NewLoggerFromConfig builds a logger for component, with any unset config options defaulted
+func NewLoggerFromConfig(component string, config LoggerConfig) *Logger {
+	defaults := DefaultLoggerConfig()
+	if config.Level == (AtomicLevel{}) {
+		config.Level = defaults.Level
+	}
+	if len(config.Sinks) == 0 {
+		config.Sinks = defaults.Sinks
+	}
+
+	syncers := make([]zapcore.WriteSyncer, len(config.Sinks))
+	for i, sink := range config.Sinks {
+		syncers[i] = zapcore.AddSync(sink)
+	}
+	output := zapcore.NewMultiWriteSyncer(syncers...)
+
+	var core zapcore.Core
+	switch config.Encoding {
+	case EncodingJSON:
+		core = zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), output, config.Level)
+	case EncodingConsole:
+		core = zapcore.NewCore(zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()), output, config.Level)
+	default:
+		core = ecszap.NewCore(ecszap.NewDefaultEncoderConfig(), output, config.Level)
+	}
+	if sampling := config.Sampling; sampling != nil {
+		tick := sampling.Tick
+		if tick <= 0 {
+			tick = time.Second
+		}
+		core = zapcore.NewSamplerWithOptions(core, tick, sampling.Initial, sampling.Thereafter)
+	}
+
+	newId := uuid.New().String()
+	logger := zap.New(core, zap.AddCaller(), zap.AddCallerSkip(config.CallerSkip)).With(
+		zap.String("component", component),
+		zap.String("id", newId),
+	).With(config.Fields...)
+	if config.RateLimit != nil { // after the fields, so summaries carry them
+		return (&Logger{logger, newId}).RateLimited(*config.RateLimit)
+	}
+	return &Logger{logger, newId}
+}
@@ -0,0 +1,171 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func sinks(buffers ...*bytes.Buffer) []io.Writer {
+	writers := make([]io.Writer, len(buffers))
+	for i, buf := range buffers {
+		writers[i] = buf
+	}
+	return writers
+}
+
+// logThroughWrapper stands in for a helper wrapping the logger, which CallerSkip should see past
+func logThroughWrapper(logger *Logger, msg string) {
+	logger.Info(msg)
+}
+
+func TestLoggerConfig(t *testing.T) {
+	t.Run("encodings", func(t *testing.T) {
+		tests := map[Encoding]func(t *testing.T, out string){
+			EncodingECS: func(t *testing.T, out string) {
+				assert.Contains(t, out, `"log.level":"info"`)
+				assert.Contains(t, out, `"message":"hello"`)
+			},
+			EncodingJSON: func(t *testing.T, out string) {
+				assert.Contains(t, out, `"level":"info"`)
+				assert.Contains(t, out, `"msg":"hello"`)
+			},
+			EncodingConsole: func(t *testing.T, out string) {
+				assert.Contains(t, out, "INFO")
+				assert.Contains(t, out, "hello")
+				assert.False(t, json.Valid([]byte(out)))
+			},
+		}
+		for encoding, check := range tests {
+			t.Run(string(encoding), func(t *testing.T) {
+				var buf bytes.Buffer
+				logger := NewLoggerFromConfig("test", LoggerConfig{Sinks: sinks(&buf), Encoding: encoding})
+				logger.Info("hello")
+				check(t, buf.String())
+			})
+		}
+	})
+	t.Run("level, fields and sinks", func(t *testing.T) {
+		var a, b bytes.Buffer
+		level := NewAtomicLevel(zapcore.WarnLevel)
+		logger := NewLoggerFromConfig("test", LoggerConfig{
+			Level:    level,
+			Sinks:    sinks(&a, &b),
+			Encoding: EncodingJSON,
+			Fields:   []zap.Field{zap.String("region", "us-east-1")},
+		})
+		logger.Info("dropped")
+		logger.Warn("kept")
+		level.SetLevel(zapcore.DebugLevel)
+		logger.Debug("kept after the level changed")
+
+		entries := decodeLines(t, &a)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "kept", entries[0]["msg"])
+		assert.Equal(t, "us-east-1", entries[0]["region"])
+		assert.Equal(t, "test", entries[0]["component"])
+		assert.Equal(t, a.String(), b.String(), "every sink receives every entry")
+	})
+	t.Run("sampling", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewLoggerFromConfig("test", LoggerConfig{
+			Sinks:    sinks(&buf),
+			Encoding: EncodingJSON,
+			Sampling: &SamplingConfig{Initial: 2, Thereafter: 5},
+		})
+		for range 12 {
+			logger.Info("noisy")
+		}
+		assert.Len(t, decodeLines(t, &buf), 4, "the first 2, then the 5th and 10th after those")
+	})
+	t.Run("caller skip", func(t *testing.T) {
+		var buf bytes.Buffer
+		skipping := NewLoggerFromConfig("test", LoggerConfig{Sinks: sinks(&buf), Encoding: EncodingJSON, CallerSkip: 1})
+		notSkipping := NewLoggerFromConfig("test", LoggerConfig{Sinks: sinks(&buf), Encoding: EncodingJSON})
+		_, file, line, _ := runtime.Caller(0)
+		logThroughWrapper(skipping, "from a wrapper") // must stay on the line after runtime.Caller
+		logThroughWrapper(notSkipping, "from a wrapper")
+
+		entries := decodeLines(t, &buf)
+		require.Len(t, entries, 2)
+		callSite := fmt.Sprintf("%s/%s:%d", filepath.Base(filepath.Dir(file)), filepath.Base(file), line+1)
+		assert.Equal(t, callSite, entries[0]["caller"], "the wrapper's caller is reported")
+		assert.NotEqual(t, entries[0]["caller"], entries[1]["caller"], "without a skip, the wrapper itself is reported")
+	})
+	t.Run("default level", func(t *testing.T) {
+		assert.Equal(t, DefaultLevel(), DefaultLoggerConfig().Level)
+		var buf bytes.Buffer
+		logger := NewLoggerFromConfig("test", LoggerConfig{Sinks: sinks(&buf), Encoding: EncodingJSON})
+		logger.Debug("dropped")
+		DefaultLevel().SetLevel(zapcore.DebugLevel)
+		t.Cleanup(func() { DefaultLevel().SetLevel(zapcore.InfoLevel) })
+		logger.Debug("kept")
+
+		entries := decodeLines(t, &buf)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "kept", entries[0]["msg"])
+	})
+	t.Run("NewLogger reads the config from ctx", func(t *testing.T) {
+		var buf bytes.Buffer
+		ctx := WithLoggerConfig(context.Background(), LoggerConfig{Sinks: sinks(&buf), Encoding: EncodingConsole})
+		NewLogger(ctx, "svc").Info("hello")
+		assert.Contains(t, buf.String(), "hello")
+		assert.Equal(t, EncodingECS, GetLoggerConfig(context.Background()).Encoding)
+	})
+}
+
+func TestLevelHandler(t *testing.T) {
+	level := NewAtomicLevel(zapcore.InfoLevel)
+	handler := LevelHandler(level)
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return recorder
+	}
+
+	res := serve(http.MethodGet, "/", "")
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.JSONEq(t, `{"level":"info"}`, res.Body.String())
+
+	res = serve(http.MethodPut, "/", `{"level":"debug"}`)
+	assert.JSONEq(t, `{"level":"debug"}`, res.Body.String())
+	assert.Equal(t, zapcore.DebugLevel, level.Level())
+
+	res = serve(http.MethodPost, "/?level=error", "")
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, zapcore.ErrorLevel, level.Level())
+
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPut, "/", `{"level":"loud"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/", `{}`).Code, "an empty body does not reset to info")
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/", `{"levle":"debug"}`).Code, "misspelled")
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/", ``).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodDelete, "/", "").Code)
+	assert.Equal(t, zapcore.ErrorLevel, level.Level())
+}
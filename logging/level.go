@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// AtomicLevel is a log level that can be changed at runtime, safely while logging
+type AtomicLevel = zap.AtomicLevel
+
+func NewAtomicLevel(level zapcore.Level) AtomicLevel {
+	return zap.NewAtomicLevelAt(level)
+}
+
+var defaultLevel = NewAtomicLevel(zapcore.InfoLevel)
+
+// DefaultLevel is shared by every logger built without a Level in its config, Info unless changed.
+// Serve it with LevelHandler to change the level of the default loggers at runtime
+func DefaultLevel() AtomicLevel {
+	return defaultLevel
+}
+
+type levelPayload struct {
+	Level zapcore.Level `json:"level"`
+}
+
+// levelRequest requires the level, so a body without one cannot reset the level to Info
+type levelRequest struct {
+	Level *zapcore.Level `json:"level"`
+}
+
+// LevelHandler reports level on GET and changes it on PUT or POST, from either a {"level":"debug"} body or a
+// ?level=debug query, e.g. to turn on debug logging on a live task. A body missing the level or holding any other
+// field is rejected
+func LevelHandler(level AtomicLevel) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			requested, err := requestedLevel(r)
+			if err != nil {
+				http.Error(w, "invalid level: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			previous := level.Level()
+			level.SetLevel(requested)
+			GetSugaredLogger(r.Context()).Infow("log level changed", "from", previous.String(), "to", requested.String())
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			http.Error(w, "only GET, PUT and POST are supported", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(levelPayload{Level: level.Level()})
+	})
+}
+
+// requestedLevel reads the level from the ?level query, or else from the body, which must hold only the level
+func requestedLevel(r *http.Request) (zapcore.Level, error) {
+	var requested zapcore.Level
+	if query := r.URL.Query().Get("level"); query != "" {
+		err := requested.UnmarshalText([]byte(query))
+		return requested, err
+	}
+	var payload levelRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&payload); err != nil {
+		return requested, err
+	}
+	if payload.Level == nil {
+		return requested, errors.New(`the body must set "level"`)
+	}
+	return *payload.Level, nil
+}
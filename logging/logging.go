@@ -3,9 +3,7 @@ package logging
 import (
 	"context"
 	"github.com/google/uuid"
-	"go.elastic.co/ecszap"
 	"go.uber.org/zap"
-	"os"
 )
 
 type Logger struct {
@@ -13,9 +11,10 @@ type Logger struct {
 	LevelId string
 }
 
+// loggerKey stores the *Logger in a context. GetSugaredLogger derives from the same logger, so they always match
+type loggerKey struct{}
+
 const (
-	loggerKey        string = "logger"
-	sugaredLoggerKey string = "sugaredLogger"
 	// TODO: other consts?
 	StatusCode      string = "statusCode"
 	TaskTime        string = "taskTime"
@@ -27,9 +26,10 @@ const (
 	ParentRequestId string = "parentRequestId"
 )
 
-// TODO: USE SetLogger(ctx, log) AFTER!
+// NewLogger builds a logger from the config stored in ctx by WithLoggerConfig, or the default config.
+// Use SetLogger(ctx, log) after to make it available to everything using ctx.
 func NewLogger(ctx context.Context, serviceName string) *Logger {
-	return LoggerFactoryFor(serviceName)
+	return NewLoggerFromConfig(serviceName, GetLoggerConfig(ctx))
 }
 
 func (logger *Logger) Fork(name string) (*Logger, string) {
@@ -85,24 +85,22 @@ func (logger *Logger) WithField(field zap.Field) *Logger {
 	}
 }
 
+// LoggerFactoryFor builds a logger writing ECS JSON to stdout at DefaultLevel(), see NewLoggerFromConfig for other options
 func LoggerFactoryFor(component string) *Logger {
-	encoderConfig := ecszap.NewDefaultEncoderConfig()
-	core := ecszap.NewCore(encoderConfig, os.Stdout, zap.InfoLevel)
-	logger := zap.New(core, zap.AddCaller())
-	newId := uuid.New().String()
-	logger = logger.With(
-		zap.String("component", component),
-		zap.String("id", newId), // TODO: ????
-	)
-	return &Logger{logger, newId}
+	return NewLoggerFromConfig(component, DefaultLoggerConfig())
 }
 
 func SetLogger(parent context.Context, logger *Logger) context.Context {
-	return context.WithValue(parent, loggerKey, logger)
+	return context.WithValue(parent, loggerKey{}, logger)
+}
+
+// With returns a context whose logger adds fields to every entry
+func With(parent context.Context, fields ...zap.Field) context.Context {
+	return SetLogger(parent, GetLogger(parent).With(fields...))
 }
 
 func GetLogger(ctx context.Context) *Logger {
-	v := ctx.Value(loggerKey)
+	v := ctx.Value(loggerKey{})
 	if logger, ok := v.(*Logger); ok {
 		return logger
 	}
@@ -110,14 +108,12 @@ func GetLogger(ctx context.Context) *Logger {
 	return &Logger{Logger: zap.NewNop()}
 }
 
+// SetSugaredLogger stores the logger behind a sugared logger, so GetLogger returns it too
 func SetSugaredLogger(parent context.Context, logger *zap.SugaredLogger) context.Context {
-	return context.WithValue(parent, sugaredLoggerKey, logger)
+	return SetLogger(parent, &Logger{logger.Desugar(), GetLogger(parent).LevelId})
 }
 
+// GetSugaredLogger returns the logger stored in ctx, sugared
 func GetSugaredLogger(ctx context.Context) *zap.SugaredLogger {
-	v := ctx.Value(sugaredLoggerKey)
-	if logger, ok := v.(*zap.SugaredLogger); ok {
-		return logger
-	}
 	return GetLogger(ctx).Sugar()
 }
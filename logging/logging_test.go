@@ -0,0 +1,43 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestContextLoggers(t *testing.T) {
+	t.Run("sugared loggers come from the stored logger", func(t *testing.T) {
+		log := NewTestLogger(t)
+		ctx := log.Install(context.Background())
+		assert.Same(t, log.Logger, GetLogger(ctx))
+		GetSugaredLogger(ctx).Infow("sugared", "n", 1)
+		assert.True(t, log.FieldEquals("sugared", "n", 1))
+	})
+
+	t.Run("SetSugaredLogger also sets GetLogger", func(t *testing.T) {
+		log := NewTestLogger(t)
+		ctx := SetSugaredLogger(context.Background(), log.Sugar().With("via", "sugar"))
+		GetLogger(ctx).Info("plain")
+		assert.True(t, log.FieldEquals("plain", "via", "sugar"))
+	})
+
+	t.Run("With enriches a copy", func(t *testing.T) {
+		log := NewTestLogger(t)
+		parent := log.Install(context.Background())
+		ctx := With(parent, zap.String(RequestId, "req-1"), zap.Int(StatusCode, 200))
+
+		GetSugaredLogger(ctx).Info("child")
+		GetLogger(parent).Info("parent")
+		assert.True(t, log.FieldEquals("child", RequestId, "req-1"))
+		assert.True(t, log.FieldEquals("child", StatusCode, 200))
+		assert.NotContains(t, log.Entries()[1].ContextMap(), RequestId, "the parent context's logger is unchanged")
+	})
+
+	t.Run("string keys do not collide", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "logger", &Logger{Logger: zap.NewExample()}) //nolint:staticcheck
+		assert.Equal(t, zap.NewNop().Core(), GetLogger(ctx).Core())
+	})
+}
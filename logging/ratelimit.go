@@ -0,0 +1,223 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	SuppressedMessage string = "suppressed.message"
+	SuppressedCount   string = "suppressed.count"
+)
+
+// RateLimitConfig keeps the first First entries with the same key per Interval.
+// The rest are dropped and counted, then reported in one "suppressed N similar messages" entry when the interval ends.
+type RateLimitConfig struct {
+	First    int           // entries kept per key per interval, defaults to 10
+	Interval time.Duration // defaults to 1 minute
+	// Key groups similar entries, defaults to the logger name, level and message.
+	// Fields are not available here, so noisy messages should keep their details in fields, not the message
+	Key func(zapcore.Entry) string
+}
+
+func (config RateLimitConfig) withDefaults() RateLimitConfig {
+	if config.First <= 0 {
+		config.First = 10
+	}
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+	if config.Key == nil {
+		config.Key = func(entry zapcore.Entry) string {
+			return entry.LoggerName + "|" + entry.Level.String() + "|" + entry.Message
+		}
+	}
+	return config
+}
+
+// NewRateLimitedCore wraps core so that noisy entries are rate limited by key, see RateLimitConfig.
+// Cores derived from it with With share its limits.
+func NewRateLimitedCore(core zapcore.Core, config RateLimitConfig) zapcore.Core {
+	return newRateLimiter(config).wrap(core)
+}
+
+// RateLimited returns a copy of logger that rate limits noisy entries, see RateLimitConfig
+func (logger *Logger) RateLimited(config RateLimitConfig) *Logger {
+	return &Logger{
+		logger.Logger.WithOptions(zap.WrapCore(newRateLimiter(config).wrap)),
+		logger.LevelId,
+	}
+}
+
+// WithRateLimit rate limits the logger stored in ctx, so existing GetLogger and GetSugaredLogger callers are limited too
+func WithRateLimit(parent context.Context, config RateLimitConfig) context.Context {
+	return SetLogger(parent, GetLogger(parent).RateLimited(config))
+}
+
+type rateLimiter struct {
+	config    RateLimitConfig
+	root      zapcore.Core // the wrapped core, without the fields of cores derived with With, for writing summaries
+	mu        sync.Mutex
+	windows   map[string]*rateWindow
+	lastSweep time.Time
+}
+
+// rateWindow counts the entries with one key during one interval
+type rateWindow struct {
+	start      time.Time
+	count      int
+	suppressed int
+	entry      zapcore.Entry // the first suppressed entry, reported in the summary
+	timer      *time.Timer   // reports the summary when the interval ends
+}
+
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	return &rateLimiter{
+		config:  config.withDefaults(),
+		windows: map[string]*rateWindow{},
+	}
+}
+
+// wrap must be called once per limiter, with the core its summaries are written to
+func (limiter *rateLimiter) wrap(core zapcore.Core) zapcore.Core {
+	limiter.root = core
+	return &rateLimitedCore{Core: core, limiter: limiter}
+}
+
+// allow counts entry against its key, returning whether it should be written.
+// Summaries for intervals that ended are returned to be written outside the lock.
+func (limiter *rateLimiter) allow(entry zapcore.Entry) (bool, []*rateWindow) {
+	now := entry.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	key := limiter.config.Key(entry)
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	var ended []*rateWindow
+	if now.Sub(limiter.lastSweep) >= limiter.config.Interval { // forget keys that have gone quiet
+		limiter.lastSweep = now
+		for k, window := range limiter.windows {
+			if now.Sub(window.start) >= limiter.config.Interval {
+				ended = append(ended, limiter.end(k, window))
+			}
+		}
+	}
+
+	window, ok := limiter.windows[key]
+	if ok && now.Sub(window.start) >= limiter.config.Interval {
+		ended = append(ended, limiter.end(key, window))
+		ok = false
+	}
+	if !ok {
+		window = &rateWindow{start: now}
+		limiter.windows[key] = window
+	}
+
+	window.count++
+	if window.count <= limiter.config.First {
+		return true, ended
+	}
+	window.suppressed++
+	if window.timer == nil {
+		window.entry = entry
+		window.timer = time.AfterFunc(limiter.config.Interval-now.Sub(window.start), func() {
+			limiter.mu.Lock()
+			ended := limiter.end(key, window)
+			limiter.mu.Unlock()
+			limiter.report(ended)
+		})
+	}
+	return false, ended
+}
+
+// end removes window if it is still the current one for key. Must be called with the lock held
+func (limiter *rateLimiter) end(key string, window *rateWindow) *rateWindow {
+	if limiter.windows[key] == window {
+		delete(limiter.windows, key)
+	}
+	if window.timer != nil {
+		window.timer.Stop()
+	}
+	return window.take()
+}
+
+// take copies window for its summary, then resets its suppressed count so each entry is only reported once.
+// Must be called with the lock held
+func (window *rateWindow) take() *rateWindow {
+	taken := *window
+	window.suppressed = 0
+	return &taken
+}
+
+// flush returns the summaries of every window so far without ending them, so the limits carry on
+func (limiter *rateLimiter) flush() []*rateWindow {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	summaries := make([]*rateWindow, 0, len(limiter.windows))
+	for _, window := range limiter.windows {
+		if window.suppressed > 0 {
+			summaries = append(summaries, window.take())
+		}
+	}
+	return summaries
+}
+
+// report writes a summary of the window's suppressed entries, if there were any, through the root core so it does not
+// carry the fields of whichever derived core the first suppressed entry came from
+func (limiter *rateLimiter) report(window *rateWindow) {
+	if window.suppressed == 0 {
+		return
+	}
+	summary := zapcore.Entry{
+		Level:      window.entry.Level,
+		Time:       time.Now(),
+		LoggerName: window.entry.LoggerName,
+		Message:    fmt.Sprintf("suppressed %d similar messages", window.suppressed),
+	}
+	if checked := limiter.root.Check(summary, nil); checked != nil {
+		checked.Write(
+			zap.String(SuppressedMessage, window.entry.Message),
+			zap.Int(SuppressedCount, window.suppressed),
+		)
+	}
+}
+
+type rateLimitedCore struct {
+	zapcore.Core
+	limiter *rateLimiter
+}
+
+func (core *rateLimitedCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitedCore{Core: core.Core.With(fields), limiter: core.limiter}
+}
+
+func (core *rateLimitedCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !core.Enabled(entry.Level) {
+		return checked
+	}
+	allowed, ended := core.limiter.allow(entry)
+	for _, window := range ended {
+		core.limiter.report(window)
+	}
+	if !allowed {
+		return checked
+	}
+	return core.Core.Check(entry, checked)
+}
+
+// Sync reports the entries suppressed so far in every interval in progress before syncing. The intervals carry on,
+// so syncing does not reset the limits
+func (core *rateLimitedCore) Sync() error {
+	for _, window := range core.limiter.flush() {
+		core.limiter.report(window)
+	}
+	return core.Core.Sync()
+}
@@ -0,0 +1,154 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// lockedBuffer lets the summary timers write while the test reads
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) entries(t *testing.T) []map[string]any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return decodeLines(t, bytes.NewBuffer(b.buf.Bytes()))
+}
+
+func TestRateLimit(t *testing.T) {
+	newLogger := func(buf *lockedBuffer, config RateLimitConfig) *Logger {
+		return NewLoggerFromConfig("test", LoggerConfig{Sinks: []io.Writer{buf}, Encoding: EncodingJSON, RateLimit: &config})
+	}
+
+	t.Run("summarises suppressed entries on Sync", func(t *testing.T) {
+		var buf lockedBuffer
+		logger := newLogger(&buf, RateLimitConfig{First: 3, Interval: time.Hour})
+		for i := range 50 {
+			logger.Error("aws error", zap.Int("attempt", i))
+			logger.Info("other")
+		}
+		require.NoError(t, logger.Sync())
+
+		entries := buf.entries(t)
+		require.Len(t, entries, 8, "3 of each message then a summary of each")
+		for _, entry := range entries[:6] {
+			assert.Contains(t, []any{"aws error", "other"}, entry["msg"])
+		}
+		summaries := entries[6:]
+		assert.ElementsMatch(t, []any{"aws error", "other"}, []any{summaries[0][SuppressedMessage], summaries[1][SuppressedMessage]})
+		for _, summary := range summaries {
+			assert.Equal(t, "suppressed 47 similar messages", summary["msg"])
+			assert.Equal(t, 47.0, summary[SuppressedCount])
+			if summary[SuppressedMessage] == "aws error" {
+				assert.Equal(t, "error", summary["level"], "summaries keep the level of what they suppressed")
+			}
+		}
+
+		require.NoError(t, logger.Sync())
+		assert.Len(t, buf.entries(t), 8, "summaries are only reported once")
+	})
+
+	t.Run("Sync does not reset the limits", func(t *testing.T) {
+		var buf lockedBuffer
+		logger := newLogger(&buf, RateLimitConfig{First: 2, Interval: time.Hour})
+		for range 5 {
+			logger.Error("aws error")
+		}
+		require.NoError(t, logger.Sync())
+		for range 4 {
+			logger.Error("aws error")
+		}
+		require.NoError(t, logger.Sync())
+
+		entries := buf.entries(t)
+		require.Len(t, entries, 4, "2 kept, then only summaries")
+		assert.Equal(t, 3.0, entries[2][SuppressedCount])
+		assert.Equal(t, 4.0, entries[3][SuppressedCount], "counted since the last Sync")
+	})
+
+	t.Run("summarises when the interval ends", func(t *testing.T) {
+		var buf lockedBuffer
+		logger := newLogger(&buf, RateLimitConfig{First: 1, Interval: 50 * time.Millisecond})
+		for range 5 {
+			logger.Warn("noisy")
+		}
+		assert.Eventually(t, func() bool { return len(buf.entries(t)) == 2 }, time.Second, 10*time.Millisecond)
+		assert.Equal(t, "suppressed 4 similar messages", buf.entries(t)[1]["msg"])
+
+		logger.Warn("noisy")
+		assert.Len(t, buf.entries(t), 3, "a new interval keeps entries again")
+	})
+
+	t.Run("forked loggers share limits", func(t *testing.T) {
+		var buf lockedBuffer
+		logger := newLogger(&buf, RateLimitConfig{First: 2, Interval: time.Hour})
+		for range 5 {
+			forked, _ := logger.Fork(RequestId)
+			forked.Info("per request")
+		}
+		require.NoError(t, logger.Sync())
+		entries := buf.entries(t)
+		require.Len(t, entries, 3)
+		assert.NotEqual(t, entries[0][RequestId], entries[1][RequestId])
+		assert.Equal(t, 3.0, entries[2][SuppressedCount])
+		assert.NotContains(t, entries[2], RequestId, "summaries do not carry the fields of one suppressed entry's logger")
+		assert.Equal(t, "test", entries[2]["component"], "but do carry the rate limited logger's")
+	})
+
+	t.Run("custom keys", func(t *testing.T) {
+		var buf lockedBuffer
+		logger := newLogger(&buf, RateLimitConfig{First: 1, Interval: time.Hour, Key: func(zapcore.Entry) string { return "" }})
+		for i := range 3 {
+			logger.Info(fmt.Sprintf("message %d", i))
+		}
+		require.NoError(t, logger.Sync())
+		entries := buf.entries(t)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "message 1", entries[1][SuppressedMessage], "the first suppressed message is reported")
+	})
+
+	t.Run("disabled levels are not counted", func(t *testing.T) {
+		var buf lockedBuffer
+		logger := newLogger(&buf, RateLimitConfig{First: 1, Interval: time.Hour})
+		for range 5 {
+			logger.Debug("hidden")
+		}
+		require.NoError(t, logger.Sync())
+		assert.Empty(t, buf.entries(t))
+	})
+
+	t.Run("WithRateLimit limits existing GetSugaredLogger callers", func(t *testing.T) {
+		var buf lockedBuffer
+		ctx := SetLogger(context.Background(), NewLoggerFromConfig("test", LoggerConfig{Sinks: []io.Writer{&buf}, Encoding: EncodingJSON}))
+		ctx = WithRateLimit(ctx, RateLimitConfig{First: 2, Interval: time.Hour})
+
+		for range 10 {
+			GetSugaredLogger(ctx).Errorw("aws error", "err", "SlowDown")
+		}
+		GetLogger(ctx).Error("aws error")
+		require.NoError(t, GetLogger(ctx).Sync())
+
+		entries := buf.entries(t)
+		require.Len(t, entries, 3)
+		assert.Equal(t, "SlowDown", entries[0]["err"])
+		assert.Equal(t, 9.0, entries[2][SuppressedCount], "the sugared and plain loggers share limits")
+	})
+}
@@ -0,0 +1,117 @@
+package logging
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestLogger captures every entry in memory, so tests can check what was logged.
+// If the test fails, the captured entries are printed with the test's output.
+type TestLogger struct {
+	*Logger
+	t    testing.TB
+	logs *observer.ObservedLogs
+}
+
+// NewTestLogger builds a logger capturing every level for t. Use Install to make it available to everything using ctx
+func NewTestLogger(t testing.TB) *TestLogger {
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := &TestLogger{
+		Logger: &Logger{Logger: zap.New(core).With(zap.String("component", t.Name())), LevelId: t.Name()},
+		t:      t,
+		logs:   logs,
+	}
+	t.Cleanup(func() {
+		if t.Failed() && logs.Len() > 0 {
+			t.Logf("captured logs:\n%s", log)
+		}
+	})
+	return log
+}
+
+// Install stores the logger in ctx for GetLogger and GetSugaredLogger
+func (log *TestLogger) Install(parent context.Context) context.Context {
+	return SetLogger(parent, log.Logger)
+}
+
+// Entries returns everything captured so far
+func (log *TestLogger) Entries() []observer.LoggedEntry {
+	return log.logs.All()
+}
+
+// Messages returns the message of everything captured so far
+func (log *TestLogger) Messages() []string {
+	entries := log.logs.All()
+	messages := make([]string, len(entries))
+	for i, entry := range entries {
+		messages[i] = entry.Message
+	}
+	return messages
+}
+
+// ContainsMessage reports whether an entry with message was logged, failing the test if not
+func (log *TestLogger) ContainsMessage(message string) bool {
+	log.t.Helper()
+	if log.logs.FilterMessage(message).Len() == 0 {
+		log.t.Errorf("no entry with message %q was logged, got %q", message, log.Messages())
+		return false
+	}
+	return true
+}
+
+// FieldEquals reports whether an entry with message has the field key equal to value, failing the test if not.
+// value is compared as zap would log it, so FieldEquals(msg, "count", 3) matches zap.Int("count", 3).
+func (log *TestLogger) FieldEquals(message, key string, value any) bool {
+	log.t.Helper()
+	expected := zapcore.NewMapObjectEncoder()
+	zap.Any(key, value).AddTo(expected)
+
+	entries := log.logs.FilterMessage(message).All()
+	if len(entries) == 0 {
+		log.t.Errorf("no entry with message %q was logged, got %q", message, log.Messages())
+		return false
+	}
+	for _, entry := range entries {
+		if reflect.DeepEqual(expected.Fields[key], entry.ContextMap()[key]) {
+			return true
+		}
+	}
+	actual := make([]any, len(entries))
+	for i, entry := range entries {
+		actual[i] = entry.ContextMap()[key]
+	}
+	log.t.Errorf("no entry with message %q has %s=%v, got %v", message, key, expected.Fields[key], actual)
+	return false
+}
+
+// CountAtLevel returns how many entries were logged at exactly level
+func (log *TestLogger) CountAtLevel(level zapcore.Level) int {
+	return log.logs.FilterLevelExact(level).Len()
+}
+
+// String renders the captured entries one per line
+func (log *TestLogger) String() string {
+	var lines strings.Builder
+	encoder := zapcore.NewConsoleEncoder(zapcore.EncoderConfig{
+		LevelKey:       "level",
+		MessageKey:     "msg",
+		EncodeLevel:    zapcore.CapitalLevelEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+	})
+	for _, entry := range log.logs.All() {
+		line, err := encoder.EncodeEntry(entry.Entry, entry.Context)
+		if err != nil {
+			lines.WriteString(entry.Message + "\n")
+			continue
+		}
+		lines.Write(line.Bytes())
+		line.Free()
+	}
+	return lines.String()
+}
@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// recordingT records failures and logs instead of failing the real test
+type recordingT struct {
+	testing.TB
+	failed   bool
+	errors   []string
+	logs     []string
+	cleanups []func()
+}
+
+func (t *recordingT) Helper()                 {}
+func (t *recordingT) Name() string            { return "recording" }
+func (t *recordingT) Failed() bool            { return t.failed }
+func (t *recordingT) Cleanup(f func())        { t.cleanups = append(t.cleanups, f) }
+func (t *recordingT) Logf(f string, a ...any) { t.logs = append(t.logs, fmt.Sprintf(f, a...)) }
+func (t *recordingT) Errorf(f string, a ...any) {
+	t.failed = true
+	t.errors = append(t.errors, fmt.Sprintf(f, a...))
+}
+
+func (t *recordingT) finish() {
+	for _, cleanup := range t.cleanups {
+		cleanup()
+	}
+}
+
+func TestTestLogger(t *testing.T) {
+	t.Run("captures entries from ctx", func(t *testing.T) {
+		log := NewTestLogger(t)
+		ctx := log.Install(context.Background())
+		GetSugaredLogger(ctx).Errorw("aws error", "aws.requestId", "abc")
+		forked, _ := GetLogger(ctx).Fork(RequestId)
+		forked.Info("handled", zap.Int(StatusCode, 200), zap.Duration(TaskTime, time.Second))
+		forked.Info("handled", zap.Int(StatusCode, 404))
+
+		assert.Equal(t, []string{"aws error", "handled", "handled"}, log.Messages())
+		assert.True(t, log.ContainsMessage("aws error"))
+		assert.True(t, log.FieldEquals("aws error", "aws.requestId", "abc"))
+		assert.True(t, log.FieldEquals("handled", StatusCode, 404), "any matching entry passes")
+		assert.True(t, log.FieldEquals("handled", TaskTime, time.Second))
+		assert.Equal(t, 2, log.CountAtLevel(zapcore.InfoLevel))
+		assert.Equal(t, 1, log.CountAtLevel(zapcore.ErrorLevel))
+		assert.Equal(t, 0, log.CountAtLevel(zapcore.DebugLevel))
+	})
+
+	t.Run("assertions fail the test", func(t *testing.T) {
+		rec := &recordingT{}
+		log := NewTestLogger(rec)
+		log.Info("handled", zap.Int(StatusCode, 200))
+
+		assert.False(t, log.ContainsMessage("missing"))
+		assert.False(t, log.FieldEquals("handled", StatusCode, 500))
+		assert.False(t, log.FieldEquals("missing", StatusCode, 200))
+		assert.Equal(t, []string{
+			`no entry with message "missing" was logged, got ["handled"]`,
+			`no entry with message "handled" has statusCode=500, got [200]`,
+			`no entry with message "missing" was logged, got ["handled"]`,
+		}, rec.errors)
+	})
+
+	t.Run("prints captured logs on failure", func(t *testing.T) {
+		rec := &recordingT{}
+		log := NewTestLogger(rec)
+		log.Warn("slow", zap.String("bucket", "b"))
+		rec.finish()
+		assert.Empty(t, rec.logs, "passing tests stay quiet")
+
+		rec.failed = true
+		rec.finish()
+		if assert.Len(t, rec.logs, 1) {
+			assert.Contains(t, rec.logs[0], "WARN\tslow")
+			assert.Contains(t, rec.logs[0], `"bucket": "b"`)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package recover
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/reeceappling/goUtils/v2/errorreference"
+)
+
+// callSafely runs f, converting a panic into a logged *PanicError wrapping errorreference.PanicDuringGoFunc
+func callSafely(ctx context.Context, f func(context.Context) error) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			panicErr := newPanicError(recovered, errorreference.PanicDuringGoFunc)
+			logPanic(ctx, "recovered from panic in goroutine", panicErr)
+			err = panicErr
+		}
+	}()
+	return f(ctx)
+}
+
+// Go runs f in a new goroutine. The returned channel receives f's error, or a *PanicError if f panicked, then closes.
+func Go(ctx context.Context, f func(context.Context) error) <-chan error {
+	out := make(chan error, 1)
+	go func() {
+		defer close(out)
+		out <- callSafely(ctx, f)
+	}()
+	return out
+}
+
+// Group runs functions in goroutines like errgroup, but converts their panics into *PanicError.
+// The first error cancels the Group's context, and Wait returns every error joined.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+	sem    chan struct{}
+
+	mut  sync.Mutex
+	errs []error
+}
+
+// NewGroup returns a Group and the context its functions receive.
+// limit caps how many functions run at once, with <= 0 meaning no limit.
+func NewGroup(ctx context.Context, limit int) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	group := &Group{ctx: ctx, cancel: cancel}
+	if limit > 0 {
+		group.sem = make(chan struct{}, limit)
+	}
+	return group, ctx
+}
+
+// Go runs f in a new goroutine, blocking first if the Group is at its limit
+func (g *Group) Go(f func(context.Context) error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.start(f)
+}
+
+// TryGo runs f in a new goroutine only if the Group is below its limit, reporting whether it did
+func (g *Group) TryGo(f func(context.Context) error) bool {
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		default:
+			return false
+		}
+	}
+	g.start(f)
+	return true
+}
+
+func (g *Group) start(f func(context.Context) error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+		if err := callSafely(g.ctx, f); err != nil {
+			g.addErr(err)
+		}
+	}()
+}
+
+func (g *Group) addErr(err error) {
+	g.mut.Lock()
+	defer g.mut.Unlock()
+	if len(g.errs) > 0 && errors.Is(err, context.Canceled) {
+		return // caused by the group cancelling itself after the first error
+	}
+	g.errs = append(g.errs, err)
+	if len(g.errs) == 1 {
+		g.cancel(err)
+	}
+}
+
+// Wait blocks until every function has returned, then returns their errors joined
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel(context.Canceled)
+	g.mut.Lock()
+	defer g.mut.Unlock()
+	return errors.Join(g.errs...)
+}
@@ -1,21 +1,129 @@
 package recover
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"github.com/reeceappling/goUtils/v2/logging"
+	"runtime"
 	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reeceappling/goUtils/v2/logging"
 )
 
-var recoveringFromPanicErr = errors.New("recovering from panic")
+// ErrRecoveredPanic is wrapped by every *PanicError returned from HandleRecoverAndLog
+var ErrRecoveredPanic = errors.New("recovering from panic")
 
+// HandleRecoverAndLog should be called as HandleRecoverAndLog(ctx, recover()) in a deferred function.
+// It returns nil if nothing panicked, otherwise it logs and returns a *PanicError wrapping ErrRecoveredPanic.
 func HandleRecoverAndLog(ctx context.Context, recoverResult any) (err error) {
 	if recoverResult != nil {
-		log := logging.GetSugaredLogger(ctx)
-		err = recoveringFromPanicErr
-		stacktrace := string(debug.Stack())
-		log.Errorw("recovering from panic", "message", fmt.Sprint(recoverResult), "stacktrace", stacktrace)
+		panicErr := newPanicError(recoverResult, ErrRecoveredPanic)
+		logPanic(ctx, "recovering from panic", panicErr)
+		err = panicErr
 	}
 	return
 }
+
+// Frame is one call in the stack of a panicking goroutine
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+func (frame Frame) String() string {
+	return fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line)
+}
+
+// PanicError is a recovered panic.
+// errors.Is and errors.As see both the sentinel describing where it was recovered and, if the panic value was an error, that error.
+type PanicError struct {
+	Value     any       // the value passed to panic
+	Cause     error     // Value, if it was an error
+	Stack     []byte    // the formatted stack of the panicking goroutine
+	Frames    []Frame   // the stack starting at the call that panicked
+	Goroutine int       // id of the panicking goroutine, 0 if unknown
+	Time      time.Time // when the panic was recovered
+	kind      error
+}
+
+func newPanicError(value any, kind error) *PanicError {
+	panicErr := &PanicError{
+		Value:  value,
+		Stack:  debug.Stack(),
+		Frames: panicFrames(),
+		Time:   time.Now(),
+		kind:   kind,
+	}
+	panicErr.Cause, _ = value.(error)
+	panicErr.Goroutine = goroutineId(panicErr.Stack)
+	return panicErr
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s: %v", e.kind, e.Value)
+}
+
+func (e *PanicError) Unwrap() []error {
+	if e.Cause != nil {
+		return []error{e.kind, e.Cause}
+	}
+	return []error{e.kind}
+}
+
+// TopFrame is the call that panicked, or an empty Frame if the stack is unknown.
+// Panics with the same TopFrame usually share a root cause.
+func (e *PanicError) TopFrame() Frame {
+	if len(e.Frames) == 0 {
+		return Frame{}
+	}
+	return e.Frames[0]
+}
+
+// panicFrames returns the caller's stack with everything up to and including the runtime's panic handling removed
+func panicFrames() []Frame {
+	pcs := make([]uintptr, 64)
+	callers := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+	var frames []Frame
+	for {
+		frame, more := callers.Next()
+		frames = append(frames, Frame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		if !more {
+			break
+		}
+	}
+
+	for i, frame := range frames {
+		if frame.Function == "runtime.gopanic" {
+			frames = frames[i+1:]
+			break
+		}
+	}
+	for len(frames) > 1 && strings.HasPrefix(frames[0].Function, "runtime.") { // e.g. runtime.sigpanic for nil dereferences
+		frames = frames[1:]
+	}
+	return frames
+}
+
+// goroutineId reads the id from the "goroutine 7 [running]:" header of a formatted stack
+func goroutineId(stack []byte) int {
+	header, _, _ := bytes.Cut(stack, []byte(" ["))
+	id, err := strconv.Atoi(string(bytes.TrimPrefix(header, []byte("goroutine "))))
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+func logPanic(ctx context.Context, message string, panicErr *PanicError) {
+	logging.GetSugaredLogger(ctx).Errorw(message,
+		"message", fmt.Sprint(panicErr.Value),
+		"stacktrace", string(panicErr.Stack),
+		"topFrame", panicErr.TopFrame().String(),
+		"goroutine", panicErr.Goroutine,
+	)
+}
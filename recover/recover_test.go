@@ -0,0 +1,146 @@
+package recover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/reeceappling/goUtils/v2/errorreference"
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGo(t *testing.T) {
+	ctx := context.Background()
+	errTest := errors.New("test error")
+
+	t.Run("returns the function's error", func(t *testing.T) {
+		assert.NoError(t, <-Go(ctx, func(context.Context) error { return nil }))
+		assert.ErrorIs(t, <-Go(ctx, func(context.Context) error { return errTest }), errTest)
+	})
+
+	t.Run("converts panics into errors", func(t *testing.T) {
+		err := <-Go(ctx, func(context.Context) error { panic("boom") })
+		assert.ErrorIs(t, err, errorreference.PanicDuringGoFunc)
+		var panicErr *PanicError
+		assert.ErrorAs(t, err, &panicErr)
+		assert.Equal(t, "boom", panicErr.Value)
+		assert.Contains(t, string(panicErr.Stack), "recover_test.go")
+		assert.True(t, strings.HasSuffix(panicErr.TopFrame().File, "recover_test.go"), panicErr.TopFrame().String())
+	})
+}
+
+func TestGroup(t *testing.T) {
+	ctx := context.Background()
+	errTest := errors.New("test error")
+
+	t.Run("joins every error", func(t *testing.T) {
+		group, _ := NewGroup(ctx, 0)
+		group.Go(func(context.Context) error { return errTest })
+		group.Go(func(context.Context) error { panic("boom") })
+		group.Go(func(context.Context) error { return nil })
+		err := group.Wait()
+		assert.ErrorIs(t, err, errTest)
+		assert.ErrorIs(t, err, errorreference.PanicDuringGoFunc)
+	})
+
+	t.Run("first error cancels the others", func(t *testing.T) {
+		group, groupCtx := NewGroup(ctx, 0)
+		group.Go(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		group.Go(func(context.Context) error { return errTest })
+		err := group.Wait()
+		assert.ErrorIs(t, err, errTest)
+		assert.NotErrorIs(t, err, context.Canceled, "cancellations caused by the group are not reported")
+		assert.ErrorIs(t, context.Cause(groupCtx), errTest)
+	})
+
+	t.Run("limits concurrency", func(t *testing.T) {
+		group, _ := NewGroup(ctx, 2)
+		var running, maxRunning atomic.Int32
+		for range 10 {
+			group.Go(func(context.Context) error {
+				now := running.Add(1)
+				for {
+					seen := maxRunning.Load()
+					if now <= seen || maxRunning.CompareAndSwap(seen, now) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				running.Add(-1)
+				return nil
+			})
+		}
+		assert.NoError(t, group.Wait())
+		assert.Equal(t, int32(2), maxRunning.Load())
+	})
+
+	t.Run("TryGo refuses past the limit", func(t *testing.T) {
+		group, _ := NewGroup(ctx, 1)
+		release := make(chan struct{})
+		assert.True(t, group.TryGo(func(context.Context) error { <-release; return nil }))
+		assert.False(t, group.TryGo(func(context.Context) error { return nil }))
+		close(release)
+		assert.NoError(t, group.Wait())
+	})
+}
+
+func panicsWith(value any) (err error) {
+	defer func() {
+		err = HandleRecoverAndLog(context.Background(), recover())
+	}()
+	panic(value)
+}
+
+func dereferencesNil() (err error) {
+	defer func() {
+		err = HandleRecoverAndLog(context.Background(), recover())
+	}()
+	var ptr *int
+	return errors.New(strconv.Itoa(*ptr))
+}
+
+func TestHandleRecoverAndLog(t *testing.T) {
+	t.Run("nothing recovered", func(t *testing.T) {
+		assert.NoError(t, HandleRecoverAndLog(context.Background(), nil))
+	})
+
+	t.Run("returns a structured panic error", func(t *testing.T) {
+		before := time.Now()
+		err := panicsWith("boom")
+		assert.ErrorIs(t, err, ErrRecoveredPanic)
+		assert.NotErrorIs(t, err, errorreference.PanicDuringGoFunc)
+
+		var panicErr *PanicError
+		assert.ErrorAs(t, err, &panicErr)
+		assert.Equal(t, "boom", panicErr.Value)
+		assert.Nil(t, panicErr.Cause)
+		assert.False(t, panicErr.Time.Before(before))
+		assert.Positive(t, panicErr.Goroutine)
+		assert.True(t, strings.HasSuffix(panicErr.TopFrame().Function, "recover.panicsWith"), panicErr.TopFrame().String())
+		assert.True(t, strings.HasSuffix(panicErr.TopFrame().File, "recover_test.go"))
+	})
+
+	t.Run("errors.Is works through panicked errors", func(t *testing.T) {
+		errTest := errors.New("test error")
+		err := panicsWith(fmt.Errorf("wrapped: %w", errTest))
+		assert.ErrorIs(t, err, errTest)
+		assert.ErrorIs(t, err, ErrRecoveredPanic)
+	})
+
+	t.Run("runtime panics point at the faulting call", func(t *testing.T) {
+		err := dereferencesNil()
+		var panicErr *PanicError
+		assert.ErrorAs(t, err, &panicErr)
+		var runtimeErr runtime.Error
+		assert.ErrorAs(t, err, &runtimeErr)
+		assert.True(t, strings.HasSuffix(panicErr.TopFrame().Function, "recover.dereferencesNil"), panicErr.TopFrame().String())
+	})
+}
@@ -1,48 +1,132 @@
 package channels
 
-import "sync"
+import (
+	"sync"
+	"time"
+)
 
 const (
 	SizeBuffer = 1000 // performance testing showed decrease in throughput over 1k buffer
-	// TODO: keep or remove next line?
-	//busyTimeout = 1 * time.Millisecond // the amount of time to attempt to push data to a worker before giving up
 )
 
-// Drain removes all items from all provided channels
-func Drain[T any](c ...<-chan T) { // TODO: test?
-	go func() {
-		for range c {
-		}
-	}()
+// Drain removes all items from all provided channels in the background, until each is closed
+func Drain[T any](c ...<-chan T) {
+	for _, channel := range c {
+		go func() {
+			for range channel {
+			}
+		}()
+	}
 }
 
-// Fanout makes one input channel that feeds exact copies into all the input channels.
+// Fanout makes one input channel that feeds exact copies into all the output channels.
+// Closing the input closes every output. The slowest output paces all of them, see FanoutWith to avoid that.
 // Do NOT use this for channels transporting pointers (or slices, etc) if multiple channels plan to modify the pointers
-func Fanout[T any](outputs []chan<- T) chan<- T { // TODO: test
+func Fanout[T any](outputs []chan<- T) chan<- T {
+	return FanoutWith(outputs, FanoutConfig[T]{})
+}
+
+// FanoutPolicy decides what happens to an item when an output's buffer is full
+type FanoutPolicy int
+
+const (
+	FanoutBlock      FanoutPolicy = iota // wait for room, up to SendTimeout if it is set
+	FanoutDropNewest                     // drop the incoming item
+	FanoutDropOldest                     // drop the oldest buffered item to make room
+)
+
+type FanoutConfig[T any] struct {
+	// BufferSize is the items buffered per output, so one slow output does not immediately stall the others.
+	// FanoutDropOldest buffers at least one, as it needs a buffered item to drop
+	BufferSize  int
+	Policy      FanoutPolicy  // what to do when an output's buffer is full
+	SendTimeout time.Duration // with FanoutBlock, drop an item that could not be buffered within this time. 0 waits forever
+	// Clone copies an item for every output after the first, so pointer payloads can be safely modified by each reader
+	Clone func(T) T
+	// OnDrop is called with the index of the output and the item whenever an item is dropped
+	OnDrop func(output int, dropped T)
+}
+
+// FanoutWith is Fanout with per-output buffering and slow-consumer policies
+func FanoutWith[T any](outputs []chan<- T, config FanoutConfig[T]) chan<- T {
 	input := make(chan T, SizeBuffer)
 
-	var wg sync.WaitGroup
-	wg.Add(len(outputs))
-	go func() { // background the wait so the output channel can return
-		wg.Wait() // wait for all inputs to close
-		close(input)
-	}()
+	bufferSize := config.BufferSize
+	if config.Policy == FanoutDropOldest {
+		bufferSize = max(bufferSize, 1) // an unbuffered queue has nothing to drop, so it would block like FanoutBlock
+	}
+	queues := make([]chan T, len(outputs))
+	for i, output := range outputs {
+		queue := make(chan T, bufferSize)
+		queues[i] = queue
+		go func() { // each output is fed from its own queue, so it can fall behind the others
+			defer close(output)
+			for v := range queue {
+				output <- v
+			}
+		}()
+	}
 
 	go func() {
+		defer func() {
+			for _, queue := range queues {
+				close(queue)
+			}
+		}()
 		for v := range input {
-			for _, output := range outputs {
-				output <- v
+			for i, queue := range queues {
+				item := v
+				if i > 0 && config.Clone != nil {
+					item = config.Clone(v)
+				}
+				config.enqueue(i, queue, item)
 			}
 		}
-		for _, output := range outputs {
-			close(output)
-			wg.Done()
-		}
 	}()
 
 	return input
 }
 
+func (config FanoutConfig[T]) enqueue(output int, queue chan T, item T) {
+	select {
+	case queue <- item:
+		return
+	default:
+	}
+
+	switch config.Policy {
+	case FanoutDropNewest:
+		config.drop(output, item)
+	case FanoutDropOldest:
+		for {
+			select {
+			case queue <- item:
+				return
+			case oldest := <-queue:
+				config.drop(output, oldest)
+			}
+		}
+	default:
+		if config.SendTimeout <= 0 {
+			queue <- item
+			return
+		}
+		timer := time.NewTimer(config.SendTimeout)
+		defer timer.Stop()
+		select {
+		case queue <- item:
+		case <-timer.C:
+			config.drop(output, item)
+		}
+	}
+}
+
+func (config FanoutConfig[T]) drop(output int, item T) {
+	if config.OnDrop != nil {
+		config.OnDrop(output, item)
+	}
+}
+
 // Multiplex takes a slice of input channels and returns a single channel with merged output (many inputs, one output)
 func Multiplex[T any](inputs []<-chan T) <-chan T { // TODO: test
 	output := make(chan T, SizeBuffer)
@@ -1,13 +1,130 @@
 package channels
 
-import "testing"
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// droppedItems collects what FanoutConfig.OnDrop reports
+type droppedItems struct {
+	mut   sync.Mutex
+	items map[int][]int
+}
+
+func (d *droppedItems) add(output int, item int) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	if d.items == nil {
+		d.items = map[int][]int{}
+	}
+	d.items[output] = append(d.items[output], item)
+}
+
+func (d *droppedItems) get(output int) []int {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	return slices.Clone(d.items[output])
+}
+
+// fanoutSlow sends 1..5 through FanoutWith to a single output that is only read after the input is closed and at
+// least minDropped items were dropped, returning what the output received and what was dropped
+func fanoutSlow(t *testing.T, policy FanoutPolicy, bufferSize, minDropped int) (received, dropped []int) {
+	t.Helper()
+	var drops droppedItems
+	out := make(chan int)
+	in := FanoutWith([]chan<- int{out}, FanoutConfig[int]{BufferSize: bufferSize, Policy: policy, OnDrop: drops.add})
+	for i := 1; i <= 5; i++ {
+		in <- i
+	}
+	close(in)
+	eventually(t, func() bool { return len(drops.get(0)) >= minDropped }, "items were not dropped")
+	received = collect(out)
+	return received, drops.get(0)
+}
 
 func TestChannels(t *testing.T) {
 	t.Run("Drain", func(t *testing.T) {
-		// TODO: THIS
+		a, b := make(chan int), make(chan int)
+		Drain[int](a, b)
+		for i := range 3 {
+			select {
+			case a <- i:
+			case <-time.After(time.Second):
+				t.Fatal("a was not drained")
+			}
+			select {
+			case b <- i:
+			case <-time.After(time.Second):
+				t.Fatal("b was not drained")
+			}
+		}
+		close(a)
+		close(b)
 	})
 	t.Run("Fanout", func(t *testing.T) {
-		// TODO: THIS
+		a, b := make(chan int), make(chan int)
+		in := Fanout([]chan<- int{a, b})
+		for i := range 3 {
+			in <- i
+		}
+		close(in)
+		var gotB []int
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			gotB = collect(b)
+		}()
+		assert.Equal(t, []int{0, 1, 2}, collect(a))
+		<-done
+		assert.Equal(t, []int{0, 1, 2}, gotB)
+	})
+	t.Run("FanoutDropNewest", func(t *testing.T) {
+		received, dropped := fanoutSlow(t, FanoutDropNewest, 2, 2)
+		assert.Len(t, append(received, dropped...), 5, "every item is either received or dropped")
+		assert.True(t, slices.IsSorted(received))
+		assert.Equal(t, 1, received[0], "the oldest item is kept")
+	})
+	t.Run("FanoutDropOldest", func(t *testing.T) {
+		received, dropped := fanoutSlow(t, FanoutDropOldest, 2, 2)
+		assert.Len(t, append(received, dropped...), 5, "every item is either received or dropped")
+		assert.True(t, slices.IsSorted(received))
+		assert.Equal(t, 5, received[len(received)-1], "the newest item is kept")
+
+		received, dropped = fanoutSlow(t, FanoutDropOldest, 0, 3)
+		assert.Len(t, append(received, dropped...), 5, "an unbuffered output still drops rather than blocking")
+		assert.Equal(t, 5, received[len(received)-1])
+	})
+	t.Run("FanoutSendTimeout", func(t *testing.T) {
+		var drops droppedItems
+		fast, stuck := make(chan int), make(chan int)
+		in := FanoutWith([]chan<- int{fast, stuck}, FanoutConfig[int]{SendTimeout: 5 * time.Millisecond, OnDrop: drops.add})
+		go func() {
+			defer close(in)
+			for i := range 5 {
+				in <- i
+			}
+		}()
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, collect(fast), "a stuck output does not stall the others")
+		assert.Len(t, append(collect(stuck), drops.get(1)...), 5)
+		assert.Empty(t, drops.get(0))
+	})
+	t.Run("FanoutClone", func(t *testing.T) {
+		a, b := make(chan *int, 1), make(chan *int, 1)
+		in := FanoutWith([]chan<- *int{a, b}, FanoutConfig[*int]{
+			BufferSize: 1,
+			Clone:      func(p *int) *int { v := *p; return &v },
+		})
+		original := 7
+		in <- &original
+		close(in)
+		gotA, gotB := <-a, <-b
+		assert.Same(t, &original, gotA, "the first output receives the original")
+		assert.NotSame(t, gotA, gotB)
+		assert.Equal(t, 7, *gotB)
 	})
 	t.Run("Multiplex", func(t *testing.T) {
 		// TODO: THIS
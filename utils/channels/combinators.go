@@ -0,0 +1,242 @@
+package channels
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Every combinator in this file returns an unbuffered output channel that is closed once its input is exhausted or
+// ctx is done, and its goroutines exit when ctx is done even if nothing reads the output.
+
+// send blocks until v is sent or ctx is done, reporting whether v was sent
+func send[T any](ctx context.Context, out chan<- T, v T) bool {
+	select {
+	case out <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// receive blocks until an item arrives, in closes, or ctx is done. ok is false for the latter two
+func receive[T any](ctx context.Context, in <-chan T) (v T, ok bool) {
+	select {
+	case v, ok = <-in:
+		return v, ok
+	case <-ctx.Done():
+		return v, false
+	}
+}
+
+// DrainCtx removes all items from all provided channels in the background, until each is closed or ctx is done
+func DrainCtx[T any](ctx context.Context, c ...<-chan T) {
+	for _, channel := range c {
+		go func() {
+			for _, ok := receive(ctx, channel); ok; _, ok = receive(ctx, channel) {
+			}
+		}()
+	}
+}
+
+// Merge forwards every item of every input to one output (many inputs, one output)
+func Merge[T any](ctx context.Context, inputs ...<-chan T) <-chan T {
+	out := make(chan T)
+
+	var wg sync.WaitGroup
+	wg.Add(len(inputs))
+	go func() { // background the wait so the output channel can return
+		wg.Wait()
+		close(out)
+	}()
+
+	for _, input := range inputs {
+		go func() {
+			defer wg.Done()
+			for v, ok := receive(ctx, input); ok; v, ok = receive(ctx, input) {
+				if !send(ctx, out, v) {
+					return
+				}
+			}
+		}()
+	}
+	return out
+}
+
+// Tee sends every item of in to each of n outputs (one input, many outputs).
+// Every output must be read, as the slowest output paces all of them.
+// Do NOT use this for pointers (or slices, etc) if multiple readers plan to modify them
+func Tee[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	outs := make([]chan T, n)
+	readOnly := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T)
+		readOnly[i] = outs[i]
+	}
+
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for v, ok := receive(ctx, in); ok; v, ok = receive(ctx, in) {
+			for _, out := range outs {
+				if !send(ctx, out, v) {
+					return
+				}
+			}
+		}
+	}()
+	return readOnly
+}
+
+// Batch groups items of in into slices of up to size items.
+// A batch is emitted when it is full, when maxWait has passed since its first item, or when in closes.
+func Batch[T any](ctx context.Context, in <-chan T, size int, maxWait time.Duration) <-chan []T {
+	out := make(chan []T)
+
+	go func() {
+		defer close(out)
+		var batch []T
+		var timeout <-chan time.Time
+		flush := func() bool {
+			full := batch
+			batch, timeout = nil, nil
+			return len(full) == 0 || send(ctx, out, full)
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timeout:
+				if !flush() {
+					return
+				}
+			case v, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				if len(batch) == 0 {
+					timeout = time.After(maxWait)
+				}
+				batch = append(batch, v)
+				if len(batch) >= size && !flush() {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// Throttle forwards at most rate items of in per second, leaving at least 1/rate seconds between sends.
+// Idle time is not saved up, so items arriving after a quiet period are still spaced out. rate <= 0 does not throttle
+func Throttle[T any](ctx context.Context, in <-chan T, rate float64) <-chan T {
+	out := make(chan T)
+	var interval time.Duration
+	if rate > 0 {
+		interval = time.Duration(float64(time.Second) / rate)
+	}
+
+	go func() {
+		defer close(out)
+		var lastSent time.Time
+		for v, ok := receive(ctx, in); ok; v, ok = receive(ctx, in) {
+			if wait := interval - time.Since(lastSent); !lastSent.IsZero() && wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				}
+			}
+			if !send(ctx, out, v) {
+				return
+			}
+			lastSent = time.Now()
+		}
+	}()
+	return out
+}
+
+// Debounce forwards only the latest item of a burst, once in has been quiet for the quiet duration.
+// A pending item is still sent when in closes.
+func Debounce[T any](ctx context.Context, in <-chan T, quiet time.Duration) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+		var latest T
+		var timer <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer:
+				timer = nil
+				if !send(ctx, out, latest) {
+					return
+				}
+			case v, ok := <-in:
+				if !ok {
+					if timer != nil {
+						send(ctx, out, latest)
+					}
+					return
+				}
+				latest, timer = v, time.After(quiet)
+			}
+		}
+	}()
+	return out
+}
+
+// Take forwards the first n items of in, then closes the output without reading any further
+func Take[T any](ctx context.Context, in <-chan T, n int) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+		for range n {
+			v, ok := receive(ctx, in)
+			if !ok || !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// FilterChan forwards only the items of in for which keep returns true
+func FilterChan[T any](ctx context.Context, in <-chan T, keep func(T) bool) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+		for v, ok := receive(ctx, in); ok; v, ok = receive(ctx, in) {
+			if keep(v) && !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// MapChan forwards mapFunc(item) for every item of in
+func MapChan[I, O any](ctx context.Context, in <-chan I, mapFunc func(I) O) <-chan O {
+	out := make(chan O)
+
+	go func() {
+		defer close(out)
+		for v, ok := receive(ctx, in); ok; v, ok = receive(ctx, in) {
+			if !send(ctx, out, mapFunc(v)) {
+				return
+			}
+		}
+	}()
+	return out
+}
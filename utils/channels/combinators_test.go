@@ -0,0 +1,167 @@
+package channels
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+// endless never closes, so only cancellation can stop whatever reads it
+func endless(ctx context.Context) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for i := 0; ; i++ {
+			if !send(ctx, out, i) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// exitsOnCancel starts a combinator over an endless input, reads one item if read is set, abandons the output,
+// then cancels and checks every goroutine exits
+func exitsOnCancel(t *testing.T, read bool, start func(ctx context.Context, in <-chan int) <-chan int) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	out := start(ctx, endless(ctx))
+	if read {
+		<-out
+	}
+	cancel()
+	eventually(t, func() bool { return runtime.NumGoroutine() <= before }, "goroutines leaked after cancellation")
+	for range out { // the output must be closed
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("DrainCtx", func(t *testing.T) {
+		before := runtime.NumGoroutine()
+		ctx, cancel := context.WithCancel(ctx)
+		DrainCtx(ctx, make(chan int), make(chan int))
+		cancel()
+		eventually(t, func() bool { return runtime.NumGoroutine() <= before }, "goroutines leaked after cancellation")
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		merged := collect(Merge(ctx, feed(1, 2, 3), feed(4, 5)))
+		slices.Sort(merged)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, merged)
+
+		exitsOnCancel(t, true, func(ctx context.Context, in <-chan int) <-chan int {
+			return Merge(ctx, in, endless(ctx))
+		})
+	})
+
+	t.Run("Tee", func(t *testing.T) {
+		outs := Tee(ctx, feed(1, 2, 3), 2)
+		got := make([][]int, 2)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			got[1] = collect(outs[1])
+		}()
+		got[0] = collect(outs[0])
+		<-done
+		assert.Equal(t, [][]int{{1, 2, 3}, {1, 2, 3}}, got)
+
+		exitsOnCancel(t, true, func(ctx context.Context, in <-chan int) <-chan int {
+			return Tee(ctx, in, 2)[0] // the second output is never read
+		})
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, collect(Batch(ctx, feed(1, 2, 3, 4, 5), 2, time.Hour)))
+
+		in := make(chan int)
+		batches := Batch(ctx, in, 10, 10*time.Millisecond)
+		in <- 1
+		in <- 2
+		assert.Equal(t, []int{1, 2}, <-batches, "a partial batch is emitted after maxWait")
+		close(in)
+		assert.Empty(t, collect(batches))
+
+		exitsOnCancel(t, false, func(ctx context.Context, in <-chan int) <-chan int {
+			return MapChan(ctx, Batch(ctx, in, 3, time.Hour), func(batch []int) int { return len(batch) })
+		})
+	})
+
+	t.Run("Throttle", func(t *testing.T) {
+		start := time.Now()
+		assert.Equal(t, []int{1, 2, 3}, collect(Throttle(ctx, feed(1, 2, 3), 50)))
+		assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond, "20ms between items")
+
+		in := make(chan int)
+		go func() {
+			defer close(in)
+			in <- 1
+			time.Sleep(60 * time.Millisecond) // idle for longer than the interval
+			in <- 2
+			in <- 3
+		}()
+		var received []time.Time
+		for range Throttle(ctx, in, 50) {
+			received = append(received, time.Now())
+		}
+		require.Len(t, received, 3)
+		assert.GreaterOrEqual(t, received[2].Sub(received[1]), 20*time.Millisecond, "idle time does not allow a burst")
+
+		exitsOnCancel(t, true, func(ctx context.Context, in <-chan int) <-chan int {
+			return Throttle(ctx, in, 1.0/3600)
+		})
+	})
+
+	t.Run("Debounce", func(t *testing.T) {
+		in := make(chan int)
+		debounced := Debounce(ctx, in, 20*time.Millisecond)
+		go func() {
+			defer close(in)
+			in <- 1
+			in <- 2
+			time.Sleep(60 * time.Millisecond)
+			in <- 3
+		}()
+		assert.Equal(t, []int{2, 3}, collect(debounced))
+
+		exitsOnCancel(t, false, func(ctx context.Context, in <-chan int) <-chan int {
+			return Debounce(ctx, in, time.Hour)
+		})
+	})
+
+	t.Run("Take", func(t *testing.T) {
+		endlessCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		assert.Equal(t, []int{0, 1, 2}, collect(Take(ctx, endless(endlessCtx), 3)))
+		assert.Equal(t, []int{1}, collect(Take(ctx, feed(1), 3)))
+
+		exitsOnCancel(t, true, func(ctx context.Context, in <-chan int) <-chan int {
+			return Take(ctx, in, 1000)
+		})
+	})
+
+	t.Run("FilterChan", func(t *testing.T) {
+		keepOdd := func(i int) bool { return i%2 == 1 }
+		assert.Equal(t, []int{1, 3, 5}, collect(FilterChan(ctx, feed(1, 2, 3, 4, 5), keepOdd)))
+
+		exitsOnCancel(t, true, func(ctx context.Context, in <-chan int) <-chan int {
+			return FilterChan(ctx, in, keepOdd)
+		})
+	})
+
+	t.Run("MapChan", func(t *testing.T) {
+		double := func(i int) int { return i * 2 }
+		assert.Equal(t, []int{2, 4, 6}, collect(MapChan(ctx, feed(1, 2, 3), double)))
+
+		exitsOnCancel(t, true, func(ctx context.Context, in <-chan int) <-chan int {
+			return MapChan(ctx, in, double)
+		})
+	})
+}
@@ -0,0 +1,190 @@
+package channels
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	recover2 "github.com/reeceappling/goUtils/v2/recover"
+	"github.com/reeceappling/goUtils/v2/utils"
+)
+
+type PoolConfig struct {
+	Workers int  // number of concurrent calls, defaults to runtime.NumCPU()
+	Ordered bool // emit results in the order their inputs were received
+}
+
+// PoolMetrics is a snapshot of a Pool's work across every Process call
+type PoolMetrics struct {
+	Queued    int // items taken from an input channel that are waiting for a worker
+	InFlight  int // items a worker is processing
+	Completed int // items processed, including failures
+	Failed    int // items whose result was an error
+}
+
+// Pool processes a channel with a fixed number of workers.
+// At most Workers items are read ahead of the workers, so a slow pool slows its producer instead of buffering without limit.
+type Pool[In, Out any] struct {
+	config PoolConfig
+	fn     func(context.Context, In) (Out, error)
+
+	queued, inFlight, completed, failed atomic.Int64
+}
+
+func NewPool[In, Out any](config PoolConfig, fn func(context.Context, In) (Out, error)) *Pool[In, Out] {
+	if config.Workers <= 0 {
+		config.Workers = runtime.NumCPU()
+	}
+	return &Pool[In, Out]{config: config, fn: fn}
+}
+
+func (pool *Pool[In, Out]) Metrics() PoolMetrics {
+	return PoolMetrics{
+		Queued:    int(pool.queued.Load()),
+		InFlight:  int(pool.inFlight.Load()),
+		Completed: int(pool.completed.Load()),
+		Failed:    int(pool.failed.Load()),
+	}
+}
+
+type poolJob[In any] struct {
+	seq  int
+	item In
+}
+
+type poolDone[Out any] struct {
+	seq    int
+	result utils.Result[Out]
+}
+
+// Process runs every item of in through the pool's function, returning a channel of results that closes once in is
+// closed and every item is processed. A panic in the function becomes that item's error.
+//
+// When ctx is done, no new items are started, the remaining input is drained in the background so its producer is not
+// blocked, and the output closes once running items return. Results not yet read are dropped.
+func (pool *Pool[In, Out]) Process(ctx context.Context, in <-chan In) <-chan utils.Result[Out] {
+	workers := pool.config.Workers
+	jobs := make(chan poolJob[In], workers)
+	results := make(chan poolDone[Out], workers)
+	out := make(chan utils.Result[Out], workers)
+
+	var window chan struct{} // when ordered, bounds how far dispatching may get ahead of the output
+	if pool.config.Ordered {
+		window = make(chan struct{}, workers*2)
+	}
+
+	go pool.dispatch(ctx, in, jobs, window)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			pool.work(ctx, jobs, results)
+		}()
+	}
+	go func() { // background the wait so the output channel can return
+		wg.Wait()
+		close(results)
+	}()
+
+	go pool.emit(ctx, results, out, window)
+
+	return out
+}
+
+func (pool *Pool[In, Out]) dispatch(ctx context.Context, in <-chan In, jobs chan<- poolJob[In], window chan struct{}) {
+	defer close(jobs)
+	for seq := 0; ; seq++ {
+		if window != nil {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				Drain(in)
+				return
+			}
+		}
+		select {
+		case <-ctx.Done():
+			Drain(in)
+			return
+		case item, ok := <-in:
+			if !ok {
+				return
+			}
+			pool.queued.Add(1)
+			select {
+			case jobs <- poolJob[In]{seq: seq, item: item}:
+			case <-ctx.Done():
+				pool.queued.Add(-1)
+				Drain(in)
+				return
+			}
+		}
+	}
+}
+
+func (pool *Pool[In, Out]) work(ctx context.Context, jobs <-chan poolJob[In], results chan<- poolDone[Out]) {
+	for job := range jobs {
+		pool.queued.Add(-1)
+		if ctx.Err() != nil {
+			continue // let the dispatcher close jobs, without starting anything new
+		}
+		pool.inFlight.Add(1)
+		result := pool.call(ctx, job.item)
+		pool.inFlight.Add(-1)
+		pool.completed.Add(1)
+		if result.Err != nil {
+			pool.failed.Add(1)
+		}
+		select {
+		case results <- poolDone[Out]{seq: job.seq, result: result}:
+		case <-ctx.Done():
+		}
+	}
+}
+
+func (pool *Pool[In, Out]) call(ctx context.Context, item In) (result utils.Result[Out]) {
+	defer func() {
+		if err := recover2.HandleRecoverAndLog(ctx, recover()); err != nil {
+			result = utils.ErroredResult[Out](err)
+		}
+	}()
+	return utils.ResultFrom(pool.fn(ctx, item))
+}
+
+func (pool *Pool[In, Out]) emit(ctx context.Context, results <-chan poolDone[Out], out chan<- utils.Result[Out], window chan struct{}) {
+	defer close(out)
+	defer func() {
+		for range results { // let workers finish if the output was abandoned
+		}
+	}()
+	send := func(result utils.Result[Out]) bool {
+		select {
+		case out <- result:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	pending, next := map[int]utils.Result[Out]{}, 0
+	for done := range results {
+		if window == nil {
+			if !send(done.result) {
+				return
+			}
+			continue
+		}
+		pending[done.seq] = done.result
+		for result, ok := pending[next]; ok; result, ok = pending[next] {
+			delete(pending, next)
+			if !send(result) {
+				return
+			}
+			next++
+			<-window
+		}
+	}
+}
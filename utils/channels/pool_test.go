@@ -0,0 +1,144 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	recover2 "github.com/reeceappling/goUtils/v2/recover"
+	"github.com/reeceappling/goUtils/v2/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func feed(items ...int) <-chan int {
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for _, item := range items {
+			in <- item
+		}
+	}()
+	return in
+}
+
+func collect[T any](c <-chan T) (out []T) {
+	for item := range c {
+		out = append(out, item)
+	}
+	return
+}
+
+// eventually fails the test if cond does not become true within a second
+func eventually(t *testing.T, cond func() bool, msg string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestPool(t *testing.T) {
+	ctx := context.Background()
+	inputs := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	slowSquare := func(_ context.Context, i int) (int, error) {
+		time.Sleep(time.Duration(10-i) * time.Millisecond) // early items finish last
+		return i * i, nil
+	}
+
+	t.Run("unordered processes everything", func(t *testing.T) {
+		pool := NewPool(PoolConfig{Workers: 4}, slowSquare)
+		results := collect(pool.Process(ctx, feed(inputs...)))
+		require.Len(t, results, len(inputs))
+		squares := utils.Set[int]{}
+		for _, result := range results {
+			require.NoError(t, result.Err)
+			squares.Add(*result.Item)
+		}
+		for _, i := range inputs {
+			assert.True(t, squares.Contains(i*i))
+		}
+		assert.Equal(t, PoolMetrics{Completed: len(inputs)}, pool.Metrics())
+	})
+
+	t.Run("ordered keeps input order", func(t *testing.T) {
+		pool := NewPool(PoolConfig{Workers: 4, Ordered: true}, slowSquare)
+		var squares []int
+		for result := range pool.Process(ctx, feed(inputs...)) {
+			require.NoError(t, result.Err)
+			squares = append(squares, *result.Item)
+		}
+		assert.Equal(t, []int{1, 4, 9, 16, 25, 36, 49, 64, 81, 100}, squares)
+	})
+
+	t.Run("errors and panics are captured per item", func(t *testing.T) {
+		errOdd := errors.New("odd")
+		pool := NewPool(PoolConfig{Workers: 2, Ordered: true}, func(_ context.Context, i int) (int, error) {
+			if i == 3 {
+				panic("three")
+			}
+			if i%2 == 1 {
+				return 0, errOdd
+			}
+			return i, nil
+		})
+		results := collect(pool.Process(ctx, feed(1, 2, 3, 4)))
+		require.Len(t, results, 4)
+		assert.ErrorIs(t, results[0].Err, errOdd)
+		assert.Equal(t, 2, *results[1].Item)
+		var panicErr *recover2.PanicError
+		assert.ErrorAs(t, results[2].Err, &panicErr)
+		assert.Equal(t, 4, *results[3].Item)
+		assert.Equal(t, 2, pool.Metrics().Failed)
+	})
+
+	t.Run("never runs more than the configured workers", func(t *testing.T) {
+		var running, maxRunning atomic.Int32
+		pool := NewPool(PoolConfig{Workers: 3}, func(_ context.Context, i int) (int, error) {
+			now := running.Add(1)
+			for seen := maxRunning.Load(); now > seen && !maxRunning.CompareAndSwap(seen, now); seen = maxRunning.Load() {
+			}
+			time.Sleep(2 * time.Millisecond)
+			running.Add(-1)
+			return i, nil
+		})
+		assert.Len(t, collect(pool.Process(ctx, feed(inputs...))), len(inputs))
+		assert.LessOrEqual(t, maxRunning.Load(), int32(3))
+	})
+
+	t.Run("cancellation drains the input and closes the output", func(t *testing.T) {
+		before := runtime.NumGoroutine()
+		ctx, cancel := context.WithCancel(ctx)
+		release := make(chan struct{})
+		pool := NewPool(PoolConfig{Workers: 2, Ordered: true}, func(ctx context.Context, i int) (int, error) {
+			<-release
+			return i, ctx.Err()
+		})
+
+		in := make(chan int)
+		producerDone := make(chan struct{})
+		go func() {
+			defer close(producerDone)
+			defer close(in)
+			for i := range 100 {
+				in <- i
+			}
+		}()
+		out := pool.Process(ctx, in)
+		eventually(t, func() bool { return pool.Metrics().InFlight == 2 }, "workers never started")
+		eventually(t, func() bool { return pool.Metrics().Queued == 2 }, "items were not queued")
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(t, 2, pool.Metrics().Queued, "only the ordering window should be read ahead")
+
+		cancel()
+		close(release)
+		collect(out)
+		<-producerDone
+		eventually(t, func() bool { return runtime.NumGoroutine() <= before }, "pool goroutines leaked")
+	})
+}
@@ -0,0 +1,56 @@
+package maps
+
+import "iter"
+
+// All iterates the keys and values of a map in no particular order
+func All[T comparable, U any](in map[T]U) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		for key, value := range in {
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// MapToSeq is the lazy version of MapToSlice
+func MapToSeq[T comparable, U any, V any](in map[T]U, transformer func(T, U) V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for key, value := range in {
+			if !yield(transformer(key, value)) {
+				return
+			}
+		}
+	}
+}
+
+// RemapSeq is the lazy version of Remap, transforming each pair of seq
+func RemapSeq[T, U, V, W any](seq iter.Seq2[T, U], transformer func(T, U) (V, W)) iter.Seq2[V, W] {
+	return func(yield func(V, W) bool) {
+		for key, value := range seq {
+			if !yield(transformer(key, value)) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq yields only the pairs of seq for which keep returns true
+func FilterSeq[T, U any](seq iter.Seq2[T, U], keep func(T, U) bool) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		for key, value := range seq {
+			if keep(key, value) && !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// Collect reads every pair of seq into a map, later pairs overwriting earlier ones with the same key
+func Collect[T comparable, U any](seq iter.Seq2[T, U]) map[T]U {
+	out := map[T]U{}
+	for key, value := range seq {
+		out[key] = value
+	}
+	return out
+}
@@ -0,0 +1,32 @@
+package maps
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeq(t *testing.T) {
+	testMap := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+
+	t.Run("All", func(t *testing.T) {
+		assert.Equal(t, testMap, Collect(All(testMap)))
+	})
+	t.Run("MapToSeq", func(t *testing.T) {
+		concatPair := func(s string, i int) string { return fmt.Sprintf(`%s%d`, s, i) }
+		var out []string
+		for v := range MapToSeq(testMap, concatPair) {
+			out = append(out, v)
+		}
+		assert.ElementsMatch(t, MapToSlice(testMap, concatPair), out)
+	})
+	t.Run("RemapSeq", func(t *testing.T) {
+		invert := func(s string, i int) (int, string) { return i, s }
+		assert.Equal(t, Remap(testMap, invert), Collect(RemapSeq(All(testMap), invert)))
+	})
+	t.Run("FilterSeq", func(t *testing.T) {
+		even := func(_ string, i int) bool { return i%2 == 0 }
+		assert.Equal(t, map[string]int{"b": 2, "d": 4}, Collect(FilterSeq(All(testMap), even)))
+	})
+}
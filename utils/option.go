@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Option is a value that may be absent. It marshals to JSON as the value, or null when absent,
+// and with the omitzero tag option an absent Option is left out entirely.
+// The zero value is an absent Option
+type Option[T any] struct {
+	value T
+	ok    bool
+}
+
+func Some[T any](t T) Option[T] {
+	return Option[T]{value: t, ok: true}
+}
+
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// OptionOf is Some of the value a pointer points to, or None for a nil pointer
+func OptionOf[T any](t *T) Option[T] {
+	if t == nil {
+		return None[T]()
+	}
+	return Some(*t)
+}
+
+func (o Option[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+func (o Option[T]) IsSome() bool {
+	return o.ok
+}
+
+func (o Option[T]) IsNone() bool {
+	return !o.ok
+}
+
+// IsZero reports whether o is absent, so the omitzero JSON tag option omits it
+func (o Option[T]) IsZero() bool {
+	return !o.ok
+}
+
+// OrElse returns the value, or defaultValue if absent. It is the Option version of Default
+func (o Option[T]) OrElse(defaultValue T) T {
+	return Default(o.Pointer(), defaultValue)
+}
+
+// Pointer returns a pointer to a copy of the value, or nil if absent
+func (o Option[T]) Pointer() *T {
+	if !o.ok {
+		return nil
+	}
+	return Pointer(o.value)
+}
+
+// MapOption applies mapFunc to a present value
+func MapOption[T, U any](o Option[T], mapFunc func(T) U) Option[U] {
+	if !o.ok {
+		return None[U]()
+	}
+	return Some(mapFunc(o.value))
+}
+
+func (o Option[T]) MarshalJSON() ([]byte, error) {
+	if !o.ok {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+func (o *Option[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = None[T]()
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*o = Some(value)
+	return nil
+}
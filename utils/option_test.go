@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOption(t *testing.T) {
+	t.Run("Get", func(t *testing.T) {
+		value, ok := Some(3).Get()
+		assert.Equal(t, 3, value)
+		assert.True(t, ok)
+
+		_, ok = None[int]().Get()
+		assert.False(t, ok)
+		assert.True(t, Option[int]{}.IsNone(), "the zero value is None")
+	})
+	t.Run("Pointers", func(t *testing.T) {
+		assert.Equal(t, Some(3), OptionOf(Pointer(3)))
+		assert.Equal(t, None[int](), OptionOf[int](nil))
+		assert.Equal(t, 3, *Some(3).Pointer())
+		assert.Nil(t, None[int]().Pointer())
+		assert.Equal(t, 4, Default(None[int]().Pointer(), 4))
+	})
+	t.Run("OrElse", func(t *testing.T) {
+		assert.Equal(t, 3, Some(3).OrElse(4))
+		assert.Equal(t, 4, None[int]().OrElse(4))
+	})
+	t.Run("MapOption", func(t *testing.T) {
+		double := func(i int) int { return i * 2 }
+		assert.Equal(t, Some(6), MapOption(Some(3), double))
+		assert.Equal(t, None[int](), MapOption(None[int](), double))
+	})
+	t.Run("JSON", func(t *testing.T) {
+		type payload struct {
+			A Option[int]    `json:"a"`
+			B Option[string] `json:"b"`
+			C Option[int]    `json:"c,omitzero"`
+		}
+		out, err := json.Marshal(payload{A: Some(0)})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"a":0,"b":null}`, string(out), "a present zero value is kept, absent values are null or omitted")
+
+		var decoded payload
+		assert.NoError(t, json.Unmarshal([]byte(`{"a":null,"b":"x"}`), &decoded))
+		assert.Equal(t, payload{B: Some("x")}, decoded)
+		assert.Error(t, json.Unmarshal([]byte(`{"a":"x"}`), &decoded))
+	})
+}
@@ -1,7 +1,6 @@
 package utils
 
 import (
-	slices2 "github.com/reeceappling/goUtils/v2/utils/slices"
 	"reflect"
 )
 
@@ -45,13 +44,14 @@ func NoneAreNil(ptrs ...any) bool {
 	return CountNotNil(ptrs...) == len(ptrs)
 }
 
-func NonNil[T any](ptrs []*T) []T {
-	keepFunc := func(p *T) bool {
-		return p != nil
+func NonNil[T any](ptrs []*T) []T { // utils/slices imports this package, so it cannot be used here
+	out := []T{}
+	for _, p := range ptrs {
+		if p != nil {
+			out = append(out, *p)
+		}
 	}
-	return slices2.Map(slices2.Filter(ptrs, keepFunc), func(i *T) T {
-		return *i
-	})
+	return out
 }
 
 func IsPointer[T any](v T) bool {
@@ -1,9 +1,17 @@
 package utils
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var NotFound = errors.New("not found")
 
+// ErrEmptyResult is the error of a Result holding neither an item nor an error, such as the zero Result
+var ErrEmptyResult = errors.New("empty result")
+
+// Result holds either an item or the error that prevented producing it, e.g. for sending through a channel.
+// A Result with neither, such as the zero value, counts as failed with ErrEmptyResult.
 type Result[T any] struct {
 	Item *T
 	Err  error
@@ -25,11 +33,92 @@ func ResultFrom[T any](t T, err error) Result[T] {
 	}
 }
 
-type ErrAnd[T any] struct { // TODO come up with better name
+// err returns r's error, or ErrEmptyResult if it holds no item either
+func (r Result[T]) err() error {
+	if r.Err == nil && r.Item == nil {
+		return ErrEmptyResult
+	}
+	return r.Err
+}
+
+// Get returns the item and error, with the item's zero value in place of a missing item
+func (r Result[T]) Get() (T, error) {
+	if err := r.err(); err != nil {
+		var zero T
+		return zero, err
+	}
+	return *r.Item, nil
+}
+
+// IsOk reports whether r holds an item and no error
+func (r Result[T]) IsOk() bool {
+	return r.err() == nil
+}
+
+// OrElse returns the item, or defaultValue if r errored
+func (r Result[T]) OrElse(defaultValue T) T {
+	if !r.IsOk() {
+		return defaultValue
+	}
+	return *r.Item
+}
+
+// Must returns the item, panicking if r errored
+func (r Result[T]) Must() T {
+	item, err := r.Get()
+	if err != nil {
+		panic(fmt.Errorf("Must called on errored result: %w", err))
+	}
+	return item
+}
+
+// MapResult applies mapFunc to a successful result's item, passing an error through unchanged
+func MapResult[T, U any](r Result[T], mapFunc func(T) U) Result[U] {
+	item, err := r.Get()
+	if err != nil {
+		return ErroredResult[U](err)
+	}
+	return SuccessfulResult(mapFunc(item))
+}
+
+// FlatMapResult is MapResult for a mapFunc that can itself fail
+func FlatMapResult[T, U any](r Result[T], mapFunc func(T) Result[U]) Result[U] {
+	item, err := r.Get()
+	if err != nil {
+		return ErroredResult[U](err)
+	}
+	return mapFunc(item)
+}
+
+// CollectResults returns every item in order, or nil and every error joined if any result errored
+func CollectResults[T any](results []Result[T]) ([]T, error) {
+	items, errs := PartitionResults(results)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return items, nil
+}
+
+// PartitionResults splits results into the items of the successful ones and the errors of the rest, both in order
+func PartitionResults[T any](results []Result[T]) (items []T, errs []error) {
+	for _, r := range results {
+		item, err := r.Get()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		items = append(items, item)
+	}
+	return
+}
+
+// Deprecated: use Result, which ResultFrom builds from the same item and error
+type ErrAnd[T any] struct {
 	Item T
 	Err  error
 }
 
+// Deprecated: use SuccessfulResult
 func ErrAndT[T any](t T) ErrAnd[T] {
 	return ErrAnd[T]{
 		Item: t,
@@ -37,6 +126,7 @@ func ErrAndT[T any](t T) ErrAnd[T] {
 	}
 }
 
+// Deprecated: use ResultFrom
 func TandErr[T any](t T, err error) ErrAnd[T] {
 	return ErrAnd[T]{
 		Item: t,
@@ -1,7 +1,75 @@
 package utils
 
-import "testing"
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func TestResult(t *testing.T) {
-	// TODO: ALL THIS
+	errBad := errors.New("bad")
+	ok, failed := SuccessfulResult(2), ErroredResult[int](errBad)
+
+	t.Run("Get", func(t *testing.T) {
+		item, err := ok.Get()
+		assert.Equal(t, 2, item)
+		assert.NoError(t, err)
+
+		item, err = failed.Get()
+		assert.Equal(t, 0, item)
+		assert.ErrorIs(t, err, errBad)
+
+		item, err = Result[int]{}.Get()
+		assert.Equal(t, 0, item)
+		assert.ErrorIs(t, err, ErrEmptyResult)
+	})
+	t.Run("OrElse", func(t *testing.T) {
+		assert.Equal(t, 2, ok.OrElse(5))
+		assert.Equal(t, 5, failed.OrElse(5))
+	})
+	t.Run("Must", func(t *testing.T) {
+		assert.Equal(t, 2, ok.Must())
+		assert.PanicsWithError(t, "Must called on errored result: bad", func() { failed.Must() })
+	})
+	t.Run("MapResult", func(t *testing.T) {
+		assert.Equal(t, SuccessfulResult("2"), MapResult(ok, strconv.Itoa))
+		assert.ErrorIs(t, MapResult(failed, strconv.Itoa).Err, errBad)
+	})
+	t.Run("FlatMapResult", func(t *testing.T) {
+		parse := func(s string) Result[int] { return ResultFrom(strconv.Atoi(s)) }
+		assert.Equal(t, 12, FlatMapResult(SuccessfulResult("12"), parse).Must())
+		assert.Error(t, FlatMapResult(SuccessfulResult("x"), parse).Err)
+		assert.ErrorIs(t, FlatMapResult(ErroredResult[string](errBad), parse).Err, errBad)
+	})
+	t.Run("CollectResults", func(t *testing.T) {
+		items, err := CollectResults([]Result[int]{ok, SuccessfulResult(3)})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{2, 3}, items)
+
+		items, err = CollectResults([]Result[int]{ok, failed, ErroredResult[int](NotFound)})
+		assert.Nil(t, items)
+		assert.ErrorIs(t, err, errBad)
+		assert.ErrorIs(t, err, NotFound)
+	})
+	t.Run("PartitionResults", func(t *testing.T) {
+		items, errs := PartitionResults([]Result[int]{failed, ok, SuccessfulResult(3)})
+		assert.Equal(t, []int{2, 3}, items)
+		assert.Equal(t, []error{errBad}, errs)
+	})
+	t.Run("zero value", func(t *testing.T) {
+		var zero Result[int]
+		assert.False(t, zero.IsOk())
+		assert.Equal(t, 5, zero.OrElse(5))
+		assert.PanicsWithError(t, "Must called on errored result: empty result", func() { zero.Must() })
+		assert.ErrorIs(t, MapResult(zero, strconv.Itoa).Err, ErrEmptyResult)
+		assert.ErrorIs(t, FlatMapResult(zero, func(int) Result[int] { return ok }).Err, ErrEmptyResult)
+
+		items, errs := PartitionResults([]Result[int]{ok, zero})
+		assert.Equal(t, []int{2}, items)
+		assert.Equal(t, []error{ErrEmptyResult}, errs)
+		_, err := CollectResults([]Result[int]{ok, zero})
+		assert.ErrorIs(t, err, ErrEmptyResult)
+	})
 }
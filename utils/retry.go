@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"context"
+	"errors"
+	"github.com/reeceappling/goUtils/v2/errorreference"
 	"math/rand"
 	"time"
 )
@@ -17,18 +20,142 @@ func Jitter() time.Duration {
 func jitter(min, maxExtra int) time.Duration {
 	return time.Duration(min+rand.Intn(maxExtra)) * time.Millisecond //nolint:gosec
 }
+
+// Retry calls f up to 10 times, sleeping for Jitter() between failures.
+//
+// Deprecated: use RetryCtx with a RetryPolicy, which respects context cancellation and can skip permanent errors.
 func Retry[T any](f func() (T, error)) (t T, err error) {
 	return RetryTimes(10, f)
 }
 
+// RetryTimes calls f up to numRetries times, sleeping for Jitter() between failures. Every error is retried.
+//
+// Deprecated: use RetryCtx with a RetryPolicy, which respects context cancellation and can skip permanent errors.
 func RetryTimes[T any](numRetries int, f func() (T, error)) (t T, err error) {
-	for i := 0; i < numRetries; i++ {
-		t, err = f()
-		if err != nil {
-			time.Sleep(Jitter())
-			continue
-		}
+	if numRetries <= 0 {
 		return
 	}
-	return
+	return RetryCtx(context.Background(), retryTimesPolicy(numRetries), func(context.Context) (T, error) {
+		return f()
+	})
+}
+
+// retryTimesPolicy keeps RetryTimes retrying any error, as it always has, unlike DefaultRetryPolicy
+func retryTimesPolicy(numRetries int) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: numRetries,
+		Backoff:     ConstantBackoff(Jitter),
+		Retryable:   func(error) bool { return true },
+	}
+}
+
+// Backoff returns how long to wait before the next attempt.
+// attempt is the 1-based number of the attempt that just failed, previous is the last delay returned (0 at first).
+type Backoff func(attempt int, previous time.Duration) time.Duration
+
+// ConstantBackoff waits for whatever the provided jitter function returns, regardless of the attempt
+func ConstantBackoff(jitterFunc func() time.Duration) Backoff {
+	return func(int, time.Duration) time.Duration {
+		return jitterFunc()
+	}
+}
+
+// ExponentialBackoff uses "full jitter": a random delay between 0 and min(ceiling, base*2^(attempt-1))
+func ExponentialBackoff(base, ceiling time.Duration) Backoff {
+	return func(attempt int, _ time.Duration) time.Duration {
+		upper := ceiling
+		if shift := attempt - 1; shift < 32 && base<<shift < ceiling && base<<shift > 0 {
+			upper = base << shift
+		}
+		return jitterBetween(0, upper)
+	}
+}
+
+// DecorrelatedJitterBackoff picks a random delay between base and 3x the previous delay, capped at ceiling
+func DecorrelatedJitterBackoff(base, ceiling time.Duration) Backoff {
+	return func(_ int, previous time.Duration) time.Duration {
+		previous = max(previous, base)
+		return min(ceiling, jitterBetween(base, previous*3))
+	}
+}
+
+// jitterBetween adapts JitterFunc to durations, returning a random duration in [lower, upper)
+func jitterBetween(lower, upper time.Duration) time.Duration {
+	lowerMs, spanMs := int(lower.Milliseconds()), int((upper - lower).Milliseconds())
+	if spanMs < 1 {
+		return lower
+	}
+	return JitterFunc(&lowerMs, &spanMs)()
+}
+
+// RetryPolicy describes how RetryCtx repeats a failing call
+type RetryPolicy struct {
+	MaxAttempts int              // total calls to make, including the first. <= 0 means no limit
+	MaxElapsed  time.Duration    // no retry is started if it would begin after this much time has passed. 0 means no limit
+	Backoff     Backoff          // nil means retry immediately
+	Retryable   func(error) bool // nil retries everything but context errors
+
+	OnAttempt func(ctx context.Context, attempt int, err error)                     // called after every attempt, err is nil on success
+	OnRetry   func(ctx context.Context, attempt int, err error, wait time.Duration) // called before waiting to retry
+}
+
+// DefaultRetryPolicy makes up to 10 attempts with decorrelated jitter between 100ms and 2s.
+// Only throttled and transient errors are retried, see errorreference.IsRetryable
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 10,
+		Backoff:     DecorrelatedJitterBackoff(100*time.Millisecond, 2*time.Second),
+		Retryable:   errorreference.IsRetryable,
+	}
+}
+
+func (policy RetryPolicy) isRetryable(err error) bool {
+	if policy.Retryable != nil {
+		return policy.Retryable(err)
+	}
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// RetryCtx calls f until it succeeds, the policy gives up, or ctx is done.
+// f is always called at least once. On failure, the returned error joins the errors of every attempt,
+// as well as ctx.Err() if the context ended the retries.
+func RetryCtx[T any](ctx context.Context, policy RetryPolicy, f func(context.Context) (T, error)) (t T, err error) {
+	start := time.Now()
+	var errs []error
+	var wait time.Duration
+	for attempt := 1; ; attempt++ {
+		t, err = f(ctx)
+		if policy.OnAttempt != nil {
+			policy.OnAttempt(ctx, attempt, err)
+		}
+		if err == nil {
+			return t, nil
+		}
+		errs = append(errs, err)
+
+		if !policy.isRetryable(err) || (policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts) {
+			return t, errors.Join(errs...)
+		}
+		if ctx.Err() != nil {
+			return t, errors.Join(append(errs, ctx.Err())...)
+		}
+
+		if policy.Backoff != nil {
+			wait = policy.Backoff(attempt, wait)
+		}
+		if policy.MaxElapsed > 0 && time.Since(start)+wait > policy.MaxElapsed {
+			return t, errors.Join(errs...)
+		}
+		if policy.OnRetry != nil {
+			policy.OnRetry(ctx, attempt, err, wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return t, errors.Join(append(errs, ctx.Err())...)
+		case <-timer.C:
+		}
+	}
 }
@@ -1,12 +1,133 @@
 package utils
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/reeceappling/goUtils/v2/errorreference"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
 
 func TestRetry(t *testing.T) {
+	errTest := errors.New("test error")
+	failTimes := func(n int) (func(context.Context) (int, error), *int) {
+		calls := 0
+		return func(context.Context) (int, error) {
+			calls++
+			if calls <= n {
+				return 0, errTest
+			}
+			return calls, nil
+		}, &calls
+	}
+
 	t.Run("Jitter(s)", func(t *testing.T) {
-		// TODO: this
+		for range 100 {
+			j := Jitter()
+			assert.GreaterOrEqual(t, j, 500*time.Millisecond)
+			assert.Less(t, j, 1500*time.Millisecond)
+		}
+		exp := ExponentialBackoff(10*time.Millisecond, 50*time.Millisecond)
+		for attempt := 1; attempt < 40; attempt++ {
+			assert.LessOrEqual(t, exp(attempt, 0), 50*time.Millisecond)
+		}
+		decorrelated := DecorrelatedJitterBackoff(10*time.Millisecond, 50*time.Millisecond)
+		var wait time.Duration
+		for attempt := 1; attempt < 40; attempt++ {
+			wait = decorrelated(attempt, wait)
+			assert.GreaterOrEqual(t, wait, 10*time.Millisecond)
+			assert.LessOrEqual(t, wait, 50*time.Millisecond)
+		}
 	})
 	t.Run("Retry", func(t *testing.T) {
-		// TODO: this
+		calls := 0
+		out, err := RetryTimes(1, func() (int, error) {
+			calls++
+			return 0, errTest
+		})
+		assert.ErrorIs(t, err, errTest)
+		assert.Equal(t, 0, out)
+		assert.Equal(t, 1, calls)
+
+		out, err = RetryTimes(0, func() (int, error) { return 1, nil })
+		assert.NoError(t, err)
+		assert.Equal(t, 0, out, "no attempts should be made")
+		policy := retryTimesPolicy(3)
+		assert.Equal(t, 3, policy.MaxAttempts)
+		assert.True(t, policy.isRetryable(errTest), "unlike DefaultRetryPolicy, unclassified errors are retried")
+		assert.True(t, policy.isRetryable(errorreference.ErrorNotFound))
+	})
+	t.Run("DefaultRetryPolicy", func(t *testing.T) {
+		policy := DefaultRetryPolicy()
+		assert.True(t, policy.isRetryable(errorreference.ErrorSlowDown))
+		assert.True(t, policy.isRetryable(fmt.Errorf("reading: %w", errorreference.ErrorTransient)))
+		assert.False(t, policy.isRetryable(errorreference.ErrorNotFound))
+		assert.False(t, policy.isRetryable(errTest))
+		assert.False(t, policy.isRetryable(context.Canceled))
+	})
+	t.Run("RetryCtx", func(t *testing.T) {
+		ctx := context.Background()
+
+		t.Run("succeeds after failures and joins nothing", func(t *testing.T) {
+			f, calls := failTimes(2)
+			out, err := RetryCtx(ctx, RetryPolicy{MaxAttempts: 5}, f)
+			assert.NoError(t, err)
+			assert.Equal(t, 3, out)
+			assert.Equal(t, 3, *calls)
+		})
+
+		t.Run("stops at max attempts with every error joined", func(t *testing.T) {
+			f, calls := failTimes(10)
+			_, err := RetryCtx(ctx, RetryPolicy{MaxAttempts: 3}, f)
+			assert.ErrorIs(t, err, errTest)
+			assert.Equal(t, 3, *calls)
+			assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 3)
+		})
+
+		t.Run("does not retry errors the policy rejects", func(t *testing.T) {
+			f, calls := failTimes(10)
+			policy := RetryPolicy{MaxAttempts: 3, Retryable: func(err error) bool { return false }}
+			_, err := RetryCtx(ctx, policy, f)
+			assert.ErrorIs(t, err, errTest)
+			assert.Equal(t, 1, *calls)
+		})
+
+		t.Run("stops waiting when the context is cancelled", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+			defer cancel()
+			f, calls := failTimes(10)
+			policy := RetryPolicy{Backoff: ConstantBackoff(func() time.Duration { return time.Hour })}
+			_, err := RetryCtx(ctx, policy, f)
+			assert.ErrorIs(t, err, errTest)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.Equal(t, 1, *calls)
+		})
+
+		t.Run("does not start a retry past max elapsed", func(t *testing.T) {
+			f, calls := failTimes(10)
+			policy := RetryPolicy{
+				MaxElapsed: 60 * time.Millisecond,
+				Backoff:    ConstantBackoff(func() time.Duration { return 40 * time.Millisecond }),
+			}
+			_, err := RetryCtx(ctx, policy, f)
+			assert.ErrorIs(t, err, errTest)
+			assert.Equal(t, 2, *calls)
+		})
+
+		t.Run("calls hooks for every attempt and retry", func(t *testing.T) {
+			f, _ := failTimes(2)
+			var attempts, retries []int
+			policy := RetryPolicy{
+				MaxAttempts: 5,
+				OnAttempt:   func(_ context.Context, attempt int, _ error) { attempts = append(attempts, attempt) },
+				OnRetry:     func(_ context.Context, attempt int, _ error, _ time.Duration) { retries = append(retries, attempt) },
+			}
+			_, err := RetryCtx(ctx, policy, f)
+			assert.NoError(t, err)
+			assert.Equal(t, []int{1, 2, 3}, attempts)
+			assert.Equal(t, []int{1, 2}, retries)
+		})
 	})
 }
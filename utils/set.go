@@ -3,6 +3,8 @@ package utils
 import (
 	"encoding/json"
 	"errors"
+	"iter"
+
 	"golang.org/x/exp/maps"
 )
 
@@ -28,6 +30,93 @@ func (s Set[T]) ToSlice() []T {
 	return maps.Keys(s)
 }
 
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+func (s Set[T]) Clone() Set[T] {
+	clone := make(Set[T], len(s))
+	for e := range s {
+		clone[e] = struct{}{}
+	}
+	return clone
+}
+
+// All iterates the set's elements in no particular order
+func (s Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := range s {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Union returns a new set of the elements in either set
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	union := s.Clone()
+	for e := range other {
+		union[e] = struct{}{}
+	}
+	return union
+}
+
+// Intersect returns a new set of the elements in both sets
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	intersection := Set[T]{}
+	for e := range small {
+		if large.Contains(e) {
+			intersection[e] = struct{}{}
+		}
+	}
+	return intersection
+}
+
+// Difference returns a new set of the elements in s but not in other
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	difference := Set[T]{}
+	for e := range s {
+		if !other.Contains(e) {
+			difference[e] = struct{}{}
+		}
+	}
+	return difference
+}
+
+// SymmetricDifference returns a new set of the elements in exactly one of the sets
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	difference := s.Difference(other)
+	for e := range other {
+		if !s.Contains(e) {
+			difference[e] = struct{}{}
+		}
+	}
+	return difference
+}
+
+// IsSubset reports whether every element of s is in other
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+	for e := range s {
+		if !other.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether both sets have the same elements. A nil set equals an empty one
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubset(other)
+}
+
 // SetOf returns a set only containing unique values of items passed in
 func SetOf[T comparable](items []T) Set[T] {
 	set := Set[T]{}
@@ -39,6 +128,15 @@ func SetFrom[T comparable](ts ...T) Set[T] {
 	return SetOf(ts)
 }
 
+// CollectSet reads every item of seq into a set
+func CollectSet[T comparable](seq iter.Seq[T]) Set[T] {
+	set := Set[T]{}
+	for e := range seq {
+		set[e] = struct{}{}
+	}
+	return set
+}
+
 // TODO: TEST ME
 func MapUniqueChildren[T any, U comparable](allItems []T, getChild func(T) U) Set[U] {
 	set := make(Set[U], 0)
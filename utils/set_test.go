@@ -1,7 +1,127 @@
 package utils
 
-import "testing"
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func TestSet(t *testing.T) {
-	// TODO: ALL THIS
+	a, b := SetFrom(1, 2, 3), SetFrom(3, 4)
+
+	t.Run("Algebra", func(t *testing.T) {
+		assert.Equal(t, SetFrom(1, 2, 3, 4), a.Union(b))
+		assert.Equal(t, SetFrom(3), a.Intersect(b))
+		assert.Equal(t, SetFrom(1, 2), a.Difference(b))
+		assert.Equal(t, SetFrom(1, 2, 4), a.SymmetricDifference(b))
+		assert.Equal(t, SetFrom(1, 2, 3), a, "operands are not modified")
+	})
+	t.Run("IsSubset", func(t *testing.T) {
+		assert.True(t, SetFrom(1, 3).IsSubset(a))
+		assert.True(t, Set[int](nil).IsSubset(a))
+		assert.False(t, b.IsSubset(a))
+		assert.False(t, a.IsSubset(SetFrom(1, 2)))
+	})
+	t.Run("Equal", func(t *testing.T) {
+		assert.True(t, a.Equal(SetFrom(3, 2, 1)))
+		assert.True(t, Set[int](nil).Equal(Set[int]{}))
+		assert.False(t, a.Equal(SetFrom(1, 2, 4)))
+		assert.False(t, a.Equal(b))
+	})
+	t.Run("Clone", func(t *testing.T) {
+		clone := a.Clone()
+		clone.Add(9)
+		assert.Equal(t, 3, a.Len())
+		assert.Equal(t, 4, clone.Len())
+	})
+	t.Run("All", func(t *testing.T) {
+		assert.ElementsMatch(t, []int{1, 2, 3}, slices.Collect(a.All()))
+		for range a.All() {
+			break // stopping early must not panic
+		}
+	})
+	t.Run("JSON", func(t *testing.T) {
+		var set Set[int]
+		assert.NoError(t, json.Unmarshal([]byte(`[1,2,2]`), &set))
+		assert.Equal(t, SetFrom(1, 2), set)
+		assert.NoError(t, json.Unmarshal([]byte(`{"3":true}`), &set), "the old map format is still accepted")
+		assert.Equal(t, SetFrom(3), set)
+	})
+}
+
+func TestSortedSet(t *testing.T) {
+	set := SortedSetFrom("c", "a", "b")
+	assert.Equal(t, []string{"a", "b", "c"}, set.ToSlice())
+	assert.Equal(t, []string{"a", "b", "c"}, slices.Collect(set.All()))
+	assert.True(t, set.Contains("b"))
+
+	for range 10 { // map order is random, so repeat to catch unsorted output
+		out, err := json.Marshal(set)
+		assert.NoError(t, err)
+		assert.Equal(t, `["a","b","c"]`, string(out))
+	}
+
+	var decoded SortedSet[string]
+	assert.NoError(t, json.Unmarshal([]byte(`["z","y"]`), &decoded))
+	assert.Equal(t, []string{"y", "z"}, decoded.ToSlice())
+
+	t.Run("the zero value can be added to", func(t *testing.T) {
+		var zero SortedSet[int]
+		zero.Add(2, 1)
+		assert.Equal(t, []int{1, 2}, zero.ToSlice())
+	})
+
+	t.Run("set operations stay sorted", func(t *testing.T) {
+		other := SortedSetFrom("d", "c")
+		results := map[string]SortedSet[string]{
+			`["a","b","c","d"]`: set.Union(other),
+			`["c"]`:             set.Intersect(other),
+			`["a","b"]`:         set.Difference(other),
+			`["a","b","d"]`:     set.SymmetricDifference(other),
+			`["a","b","c"]`:     set.Clone(),
+		}
+		for exp, result := range results {
+			for range 10 {
+				out, err := json.Marshal(result)
+				assert.NoError(t, err)
+				assert.Equal(t, exp, string(out))
+			}
+		}
+		assert.True(t, set.Intersect(other).IsSubset(set))
+		assert.True(t, set.Equal(set.Clone()))
+	})
+}
+
+func TestSyncSet(t *testing.T) {
+	var set SyncSet[int]
+	var wg sync.WaitGroup
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			set.Add(i, i+100)
+			set.Contains(i)
+			set.Remove(i + 100)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 100, set.Len())
+
+	snapshot := set.Snapshot()
+	set.Add(1000)
+	assert.Equal(t, 100, snapshot.Len(), "a snapshot does not see later changes")
+
+	out, err := json.Marshal(SyncSetFrom(1))
+	assert.NoError(t, err)
+	assert.Equal(t, `[1]`, string(out))
+	decoded := SyncSetFrom[int]()
+	assert.NoError(t, json.Unmarshal([]byte(`[4,5]`), decoded))
+	assert.ElementsMatch(t, []int{4, 5}, decoded.ToSlice())
+}
+
+func TestCollectSet(t *testing.T) {
+	assert.Equal(t, SetFrom(1, 2), CollectSet(slices.Values([]int{1, 2, 1})))
 }
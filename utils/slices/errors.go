@@ -0,0 +1,130 @@
+package slices
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+
+	recover2 "github.com/reeceappling/goUtils/v2/recover"
+	"github.com/reeceappling/goUtils/v2/utils"
+)
+
+// ErrorMode decides how the Err helpers handle a failing item
+type ErrorMode int
+
+const (
+	StopOnError ErrorMode = iota // return the first error without processing the remaining items
+	JoinErrors                   // process every item, then return every error joined with errors.Join
+)
+
+// MapErr is Map for a mapFunc that can fail.
+// With StopOnError the output is nil on error, with JoinErrors it holds the outputs of the items that succeeded, in order.
+func MapErr[I, O any](data []I, mode ErrorMode, mapFunc func(I) (O, error)) ([]O, error) {
+	out := make([]O, 0, len(data))
+	var errs []error
+	for i := range data {
+		mapped, err := mapFunc(data[i])
+		if err != nil {
+			if mode == StopOnError {
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		out = append(out, mapped)
+	}
+	return out, errors.Join(errs...)
+}
+
+// FilterErr is Filter for a keep function that can fail.
+// With StopOnError the output is nil on error, with JoinErrors items whose keep failed are left out.
+func FilterErr[T any](in []T, mode ErrorMode, keep func(T) (bool, error)) ([]T, error) {
+	var out []T
+	var errs []error
+	for i := range in {
+		toCheck := in[i]
+		kept, err := keep(toCheck)
+		if err != nil {
+			if mode == StopOnError {
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		if kept {
+			out = append(out, toCheck)
+		}
+	}
+	return out, errors.Join(errs...)
+}
+
+// ReduceErr is FoldLeft with an op that can fail, so it starts from init unlike Reduce.
+// With StopOnError the accumulator so far is returned with the error, with JoinErrors failing items are skipped.
+func ReduceErr[T, A any](data []T, init A, mode ErrorMode, op func(A, T) (A, error)) (A, error) {
+	acc := init
+	var errs []error
+	for i := range data {
+		next, err := op(acc, data[i])
+		if err != nil {
+			if mode == StopOnError {
+				return acc, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		acc = next
+	}
+	return acc, errors.Join(errs...)
+}
+
+// FoldErr is ReduceErr, named after FoldLeft
+func FoldErr[T, A any](data []T, init A, mode ErrorMode, op func(A, T) (A, error)) (A, error) {
+	return ReduceErr(data, init, mode, op)
+}
+
+// ParallelMap calls mapFunc on every item of data with up to workers calls at once (runtime.NumCPU() if <= 0).
+// The results are in the same order as data. A panic in mapFunc becomes that item's error, and items not started
+// before ctx is done get ctx's error.
+func ParallelMap[I, O any](ctx context.Context, data []I, workers int, mapFunc func(context.Context, I) (O, error)) []utils.Result[O] {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	out := make([]utils.Result[O], len(data))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	wg.Add(min(workers, len(data)))
+	for range min(workers, len(data)) {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				out[i] = callSafely(ctx, data[i], mapFunc)
+			}
+		}()
+	}
+
+dispatch:
+	for i := range data {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			for j := i; j < len(data); j++ {
+				out[j] = utils.ErroredResult[O](ctx.Err())
+			}
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	return out
+}
+
+func callSafely[I, O any](ctx context.Context, item I, mapFunc func(context.Context, I) (O, error)) (result utils.Result[O]) {
+	defer func() {
+		if err := recover2.HandleRecoverAndLog(ctx, recover()); err != nil {
+			result = utils.ErroredResult[O](err)
+		}
+	}()
+	return utils.ResultFrom(mapFunc(ctx, item))
+}
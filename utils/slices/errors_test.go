@@ -0,0 +1,108 @@
+package slices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	recover2 "github.com/reeceappling/goUtils/v2/recover"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorVariants(t *testing.T) {
+	strs := []string{"1", "x", "3", "y"}
+
+	t.Run("MapErr", func(t *testing.T) {
+		out, err := MapErr(strs, StopOnError, strconv.Atoi)
+		assert.Nil(t, out)
+		assert.ErrorContains(t, err, `"x"`)
+		assert.NotContains(t, err.Error(), `"y"`, "stops at the first error")
+
+		out, err = MapErr(strs, JoinErrors, strconv.Atoi)
+		assert.Equal(t, []int{1, 3}, out)
+		assert.ErrorContains(t, err, `"x"`)
+		assert.ErrorContains(t, err, `"y"`)
+
+		out, err = MapErr([]string{"1", "2"}, StopOnError, strconv.Atoi)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, out)
+	})
+
+	t.Run("FilterErr", func(t *testing.T) {
+		isOdd := func(s string) (bool, error) {
+			i, err := strconv.Atoi(s)
+			return i%2 == 1, err
+		}
+		out, err := FilterErr(strs, StopOnError, isOdd)
+		assert.Nil(t, out)
+		assert.Error(t, err)
+
+		out, err = FilterErr(strs, JoinErrors, isOdd)
+		assert.Equal(t, []string{"1", "3"}, out)
+		assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 2)
+	})
+
+	t.Run("ReduceErr", func(t *testing.T) {
+		sum := func(acc int, s string) (int, error) {
+			i, err := strconv.Atoi(s)
+			return acc + i, err
+		}
+		total, err := ReduceErr(strs, 10, StopOnError, sum)
+		assert.Equal(t, 11, total, "the accumulator before the error is returned")
+		assert.Error(t, err)
+
+		total, err = ReduceErr(strs, 10, JoinErrors, sum)
+		assert.Equal(t, 14, total, "failing items are skipped")
+		assert.Error(t, err)
+
+		total, err = FoldErr(strs, 10, JoinErrors, sum)
+		assert.Equal(t, 14, total, "FoldErr is the same")
+		assert.Error(t, err)
+	})
+
+	t.Run("ParallelMap", func(t *testing.T) {
+		var running, maxRunning atomic.Int64
+		double := func(ctx context.Context, i int) (int, error) {
+			now := running.Add(1)
+			defer running.Add(-1)
+			for old := maxRunning.Load(); now > old && !maxRunning.CompareAndSwap(old, now); old = maxRunning.Load() {
+			}
+			time.Sleep(time.Millisecond)
+			switch i {
+			case 3:
+				return 0, fmt.Errorf("bad %d", i)
+			case 5:
+				panic("five")
+			}
+			return i * 2, nil
+		}
+
+		results := ParallelMap(context.Background(), []int{0, 1, 2, 3, 4, 5, 6, 7}, 3, double)
+		assert.Len(t, results, 8)
+		for i, result := range results {
+			switch i {
+			case 3:
+				assert.EqualError(t, result.Err, "bad 3")
+			case 5:
+				assert.ErrorIs(t, result.Err, recover2.ErrRecoveredPanic)
+			default:
+				assert.NoError(t, result.Err)
+				assert.Equal(t, i*2, *result.Item, "results keep the input order")
+			}
+		}
+		assert.LessOrEqual(t, maxRunning.Load(), int64(3))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		for _, result := range ParallelMap(ctx, []int{1, 2, 3}, 1, double) {
+			if result.Err != nil {
+				assert.True(t, errors.Is(result.Err, context.Canceled))
+			}
+		}
+		assert.Empty(t, ParallelMap(ctx, []int{}, 2, double))
+	})
+}
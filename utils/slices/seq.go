@@ -0,0 +1,153 @@
+package slices
+
+import "iter"
+
+// The Seq functions are lazy counterparts of the slice helpers: nothing is read from the input until the output is
+// iterated, and stopping early stops reading the input. They can be chained without materialising each step.
+
+// Values iterates the items of a slice
+func Values[T any](data []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range data {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Collect reads every item of seq into a slice
+func Collect[T any](seq iter.Seq[T]) (out []T) {
+	for v := range seq {
+		out = append(out, v)
+	}
+	return
+}
+
+// MapSeq is the lazy version of Map
+func MapSeq[I, O any](seq iter.Seq[I], mapFunc func(I) O) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		for v := range seq {
+			if !yield(mapFunc(v)) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq is the lazy version of Filter
+func FilterSeq[T any](seq iter.Seq[T], keep func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if keep(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ChunkSeq is the lazy version of Chunk. Every chunk is a new slice, so chunks may be kept after iterating
+func ChunkSeq[T any](seq iter.Seq[T], chunkSize int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		var chunk []T
+		for v := range seq {
+			chunk = append(chunk, v)
+			if len(chunk) >= chunkSize {
+				if !yield(chunk) {
+					return
+				}
+				chunk = nil
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// WindowSeq is the lazy version of Sliding. Every window is a new slice, and nothing is yielded if seq has fewer
+// than groupSize items
+func WindowSeq[T any](seq iter.Seq[T], groupSize int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if groupSize <= 0 {
+			return
+		}
+		window := make([]T, 0, groupSize)
+		for v := range seq {
+			if len(window) == groupSize {
+				window = window[1:]
+			}
+			window = append(window, v)
+			if len(window) == groupSize && !yield(append([]T(nil), window...)) {
+				return
+			}
+		}
+	}
+}
+
+// ZipSeq is the lazy version of Zip, pairing items of two sequences of any types until either ends
+func ZipSeq[L, R any](left iter.Seq[L], right iter.Seq[R]) iter.Seq2[L, R] {
+	return func(yield func(L, R) bool) {
+		nextRight, stop := iter.Pull(right)
+		defer stop()
+		for l := range left {
+			r, ok := nextRight()
+			if !ok || !yield(l, r) {
+				return
+			}
+		}
+	}
+}
+
+// EnumerateSeq pairs every item of seq with its index
+func EnumerateSeq[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range seq {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// TakeWhileSeq yields items of seq until keep first returns false
+func TakeWhileSeq[T any](seq iter.Seq[T], keep func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if !keep(v) || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// UniqueSeq is the lazy version of Unique. It remembers every item yielded so far
+func UniqueSeq[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := map[T]struct{}{}
+		for v := range seq {
+			if _, exists := seen[v]; exists {
+				continue
+			}
+			seen[v] = struct{}{}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FlattenSeq yields every item of every slice of seq, e.g. every key of every page of a listing
+func FlattenSeq[T any](seq iter.Seq[[]T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for group := range seq {
+			for _, v := range group {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package slices
+
+import (
+	"iter"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// counting yields 1, 2, 3, ... forever, recording how many items were read
+func counting(read *int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 1; ; i++ {
+			*read = i
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestSeq(t *testing.T) {
+	intSlice := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	ints := Values(intSlice)
+
+	t.Run("MapSeq", func(t *testing.T) {
+		assert.Equal(t, Map(intSlice, strconv.Itoa), Collect(MapSeq(ints, strconv.Itoa)))
+	})
+	t.Run("FilterSeq", func(t *testing.T) {
+		keepOdd := func(i int) bool { return i%2 == 1 }
+		assert.Equal(t, Filter(intSlice, keepOdd), Collect(FilterSeq(ints, keepOdd)))
+	})
+	t.Run("ChunkSeq", func(t *testing.T) {
+		assert.Equal(t, Chunk(intSlice, 3), Collect(ChunkSeq(ints, 3)))
+		assert.Empty(t, Collect(ChunkSeq(Values([]int{}), 3)))
+	})
+	t.Run("WindowSeq", func(t *testing.T) {
+		assert.Equal(t, Sliding(intSlice, 3), Collect(WindowSeq(ints, 3)))
+		assert.Empty(t, Collect(WindowSeq(ints, 9)))
+	})
+	t.Run("ZipSeq", func(t *testing.T) {
+		var letters []string
+		var numbers []int
+		for l, n := range ZipSeq(Values([]string{"a", "b", "c"}), ints) {
+			letters, numbers = append(letters, l), append(numbers, n)
+		}
+		assert.Equal(t, []string{"a", "b", "c"}, letters)
+		assert.Equal(t, []int{1, 2, 3}, numbers)
+	})
+	t.Run("EnumerateSeq", func(t *testing.T) {
+		for i, v := range EnumerateSeq(ints) {
+			assert.Equal(t, intSlice[i], v)
+		}
+	})
+	t.Run("TakeWhileSeq", func(t *testing.T) {
+		var read int
+		assert.Equal(t, []int{1, 2, 3}, Collect(TakeWhileSeq(counting(&read), func(i int) bool { return i < 4 })))
+		assert.Equal(t, 4, read)
+	})
+	t.Run("UniqueSeq", func(t *testing.T) {
+		assert.Equal(t, []int{1, 2, 3}, Collect(UniqueSeq(Values([]int{1, 2, 1, 3, 2}))))
+	})
+	t.Run("FlattenSeq", func(t *testing.T) {
+		assert.Equal(t, intSlice, Collect(FlattenSeq(ChunkSeq(ints, 3))))
+	})
+	t.Run("Lazy", func(t *testing.T) {
+		var read int
+		pipeline := FlattenSeq(ChunkSeq(MapSeq(FilterSeq(counting(&read), func(i int) bool { return i%2 == 0 }),
+			func(i int) int { return i * 10 }), 2))
+		assert.Equal(t, 0, read, "nothing is read until iterated")
+		assert.Equal(t, []int{20, 40, 60}, Collect(TakeWhileSeq(pipeline, func(i int) bool { return i <= 60 })))
+		assert.Equal(t, 8, read, "only the items needed for the chunk holding 80 are read")
+	})
+}
@@ -1,6 +1,9 @@
 package slices
 
-import "golang.org/x/exp/constraints"
+import (
+	"cmp"
+	"slices"
+)
 
 // Chunk takes a slice of values and a chunkSize, then returns a slice of the values in slices of chunkSize
 // ex: Chunk({1,2,3,4,5,6,7,8},3) == {{1,2,3}{4,5,6}{7,8}}
@@ -24,8 +27,13 @@ func Chunk[T any](slice []T, chunkSize int) [][]T {
 // Sliding takes an input slice and a groupsize.         ex: sliding({1,2,3,4,5,6,7},3) == {{1,2,3},
 // For each value in the slice that has groupsize-1 values after it,                       	{2,3,4},
 // add a slice of {data[i], data[i+1],...,data[i+groupsize-1]} to the output               	{3,4,5},
-func Sliding[T any](data []T, groupSize int) [][]T { //										{4,5,6},
-	out := make([][]T, len(data)+1-groupSize) //											{5,6,7}}
+// The output is empty if groupsize is not between 1                                       	{4,5,6},
+// and len(data)                                                                           	{5,6,7}}
+func Sliding[T any](data []T, groupSize int) [][]T {
+	if groupSize <= 0 || groupSize > len(data) {
+		return [][]T{}
+	}
+	out := make([][]T, len(data)+1-groupSize)
 	for i := range out {
 		newGroup := make([]T, groupSize)
 		for n := range newGroup {
@@ -71,24 +79,24 @@ func Filter[T any](in []T, keep func(T) bool) (out []T) {
 	return
 }
 
-// TODO: changeMe
-// The result is a slice of only the values which returned true
-func FilterInPlace[T any](in []T, keep func(T) bool) (out []T) { // TODO: ensure works
+// FilterInPlace is Filter without allocating: kept values are moved to the front of the input, keeping their order,
+// and the result is the input resliced to them. The input is modified!
+func FilterInPlace[T any](in []T, keep func(T) bool) (out []T) {
 	current := 0
 	for i := range in {
 		toCheck := in[i]
 		if keep(toCheck) {
-			in[i] = toCheck
+			in[current] = toCheck
 			current++
 		}
 	}
-	return in[:current] // TODO: ensure correct
+	return in[:current]
 }
 
 // ScanLeft creates a slice of size len(data)+1, where the first item is init.
-// All other values in the output are the result of the provided operation
-func ScanLeft[T constraints.Ordered](data []T, init T, op func(T, T) T) []T {
-	out := make([]T, len(data)+1)
+// All other values in the output are the result of the provided operation on the previous output and the next value
+func ScanLeft[T, A any](data []T, init A, op func(A, T) A) []A {
+	out := make([]A, len(data)+1)
 	out[0] = init
 	for i := 0; i < len(data); i++ {
 		out[i+1] = op(out[i], data[i])
@@ -108,6 +116,109 @@ func Zip[T any](L, R []T) [][2]T {
 	return out
 }
 
+// Pair holds two values of possibly different types
+type Pair[L, R any] struct {
+	Left  L
+	Right R
+}
+
+// Zip2 is Zip for slices of different types, dropping unpaired values
+// for example L={1,2,3} R={"a","b"} becomes {{1,"a"}{2,"b"}}
+func Zip2[L, R any](left []L, right []R) []Pair[L, R] {
+	size := min(len(left), len(right))
+	out := make([]Pair[L, R], size)
+	for i := range out {
+		out[i] = Pair[L, R]{Left: left[i], Right: right[i]}
+	}
+	return out
+}
+
+// FoldLeft combines every value with an accumulator starting at init, from first to last.
+// It returns the last value ScanLeft would.
+func FoldLeft[T, A any](data []T, init A, op func(A, T) A) A {
+	acc := init
+	for i := range data {
+		acc = op(acc, data[i])
+	}
+	return acc
+}
+
+// Reduce is FoldLeft starting from the first value. ok is false, and result the zero value, if data is empty
+func Reduce[T any](data []T, op func(T, T) T) (result T, ok bool) {
+	if len(data) == 0 {
+		return result, false
+	}
+	return FoldLeft(data[1:], data[0], op), true
+}
+
+// GroupBy groups values by the key returned for each, keeping their order within each group
+func GroupBy[T any, K comparable](data []T, key func(T) K) map[K][]T {
+	out := map[K][]T{}
+	for _, v := range data {
+		k := key(v)
+		out[k] = append(out[k], v)
+	}
+	return out
+}
+
+// Partition splits data into the values which keep returned true for and the rest, both in their original order
+func Partition[T any](data []T, keep func(T) bool) (kept, rejected []T) {
+	for _, v := range data {
+		if keep(v) {
+			kept = append(kept, v)
+		} else {
+			rejected = append(rejected, v)
+		}
+	}
+	return
+}
+
+// Flatten concatenates the slices of data into one slice
+func Flatten[T any](data [][]T) []T {
+	size := 0
+	for _, group := range data {
+		size += len(group)
+	}
+	out := make([]T, 0, size)
+	for _, group := range data {
+		out = append(out, group...)
+	}
+	return out
+}
+
+// IndexBy maps each value by the key returned for it. When keys repeat, the last value wins
+func IndexBy[T any, K comparable](data []T, key func(T) K) map[K]T {
+	out := make(map[K]T, len(data))
+	for _, v := range data {
+		out[key(v)] = v
+	}
+	return out
+}
+
+// DistinctBy is Unique comparing only the key returned for each value, keeping the first value of each key
+func DistinctBy[T any, K comparable](data []T, key func(T) K) []T {
+	out := []T{}
+	seen := map[K]struct{}{}
+	for _, v := range data {
+		k := key(v)
+		if _, exists := seen[k]; !exists {
+			seen[k] = struct{}{}
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// SortedBy returns a sorted copy of data, ordered by the key returned for each value.
+// Values with equal keys keep their original order
+func SortedBy[T any, K cmp.Ordered](data []T, key func(T) K) []T {
+	out := slices.Clone(data)
+	slices.SortStableFunc(out, func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	})
+	return out
+}
+
 func ReverseOf[T any](s []T) []T {
 	out := make([]T, len(s))
 	for i, v := range s {
@@ -18,14 +18,23 @@ func TestSlices(t *testing.T) {
 	})
 
 	t.Run("Sliding", func(t *testing.T) {
-		assert.Equal(t, [][]int{
-			{1, 2, 3},
-			{2, 3, 4},
-			{3, 4, 5},
-			{4, 5, 6},
-			{5, 6, 7},
-			{6, 7, 8}}, Sliding(intSlice, 3))
-
+		tests := []struct {
+			name      string
+			data      []int
+			groupSize int
+			exp       [][]int
+		}{
+			{"groups of 3", intSlice, 3, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}, {4, 5, 6}, {5, 6, 7}, {6, 7, 8}}},
+			{"one group", []int{1, 2}, 2, [][]int{{1, 2}}},
+			{"group larger than data", []int{1, 2}, 3, [][]int{}},
+			{"empty data", nil, 1, [][]int{}},
+			{"zero group size", []int{1, 2}, 0, [][]int{}},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				assert.Equal(t, test.exp, Sliding(test.data, test.groupSize))
+			})
+		}
 	})
 
 	t.Run("Map", func(t *testing.T) {
@@ -38,12 +47,62 @@ func TestSlices(t *testing.T) {
 		assert.Equal(t, []int{1, 3, 5, 7}, Filter(intSlice, keepOdd))
 	})
 
+	t.Run("FilterInPlace", func(t *testing.T) {
+		keepOdd := func(i int) bool { return i%2 == 1 }
+		tests := []struct {
+			name string
+			data []int
+			exp  []int
+		}{
+			{"mixed", []int{1, 2, 3, 4, 5, 6, 7, 8}, []int{1, 3, 5, 7}},
+			{"kept values after removed ones", []int{2, 4, 5, 7}, []int{5, 7}},
+			{"none kept", []int{2, 4}, []int{}},
+			{"all kept", []int{1, 3}, []int{1, 3}},
+			{"empty", []int{}, []int{}},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				assert.Equal(t, test.exp, FilterInPlace(test.data, keepOdd))
+			})
+		}
+	})
+
 	t.Run("ScanLeft", func(t *testing.T) {
 		sum := func(i, j int) int { return i + j }
 		res := ScanLeft(intSlice, -1, sum)
 		exp := []int{-1, 0, 2, 5, 9, 14, 20, 27, 35}
 		assert.Equal(t, len(intSlice)+1, len(res))
 		assert.Equal(t, exp, res)
+
+		concat := func(acc string, i int) string { return acc + strconv.Itoa(i) }
+		assert.Equal(t, []string{">", ">1", ">12"}, ScanLeft([]int{1, 2}, ">", concat), "any types can be scanned")
+	})
+
+	t.Run("FoldLeft", func(t *testing.T) {
+		concat := func(acc string, i int) string { return acc + strconv.Itoa(i) }
+		assert.Equal(t, ">123", FoldLeft([]int{1, 2, 3}, ">", concat))
+		assert.Equal(t, ">", FoldLeft(nil, ">", concat))
+	})
+
+	t.Run("Reduce", func(t *testing.T) {
+		sum := func(i, j int) int { return i + j }
+		tests := []struct {
+			name  string
+			data  []int
+			exp   int
+			expOk bool
+		}{
+			{"many", intSlice, 36, true},
+			{"one", []int{4}, 4, true},
+			{"empty", nil, 0, false},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				res, ok := Reduce(test.data, sum)
+				assert.Equal(t, test.exp, res)
+				assert.Equal(t, test.expOk, ok)
+			})
+		}
 	})
 
 	t.Run("Zip", func(t *testing.T) {
@@ -53,6 +112,54 @@ func TestSlices(t *testing.T) {
 		assert.Equal(t, exp, Zip(a, b))
 	})
 
+	t.Run("Zip2", func(t *testing.T) {
+		exp := []Pair[int, string]{{1, "a"}, {2, "b"}}
+		assert.Equal(t, exp, Zip2([]int{1, 2, 3}, []string{"a", "b"}))
+		assert.Empty(t, Zip2([]int{1}, []string{}))
+	})
+
+	type person struct {
+		name string
+		age  int
+	}
+	people := []person{{"ann", 30}, {"bob", 25}, {"cat", 30}, {"ann", 41}}
+	byAge := func(p person) int { return p.age }
+	byName := func(p person) string { return p.name }
+
+	t.Run("GroupBy", func(t *testing.T) {
+		assert.Equal(t, map[int][]person{
+			25: {{"bob", 25}},
+			30: {{"ann", 30}, {"cat", 30}},
+			41: {{"ann", 41}},
+		}, GroupBy(people, byAge))
+	})
+
+	t.Run("Partition", func(t *testing.T) {
+		kept, rejected := Partition(intSlice, func(i int) bool { return i > 5 })
+		assert.Equal(t, []int{6, 7, 8}, kept)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, rejected)
+	})
+
+	t.Run("Flatten", func(t *testing.T) {
+		assert.Equal(t, intSlice, Flatten(Chunk(intSlice, 3)))
+		assert.Empty(t, Flatten[int](nil))
+	})
+
+	t.Run("IndexBy", func(t *testing.T) {
+		assert.Equal(t, map[string]person{"ann": {"ann", 41}, "bob": {"bob", 25}, "cat": {"cat", 30}}, IndexBy(people, byName))
+	})
+
+	t.Run("DistinctBy", func(t *testing.T) {
+		assert.Equal(t, []person{{"ann", 30}, {"bob", 25}, {"cat", 30}}, DistinctBy(people, byName))
+		assert.Equal(t, []person{{"ann", 30}, {"bob", 25}, {"ann", 41}}, DistinctBy(people, byAge))
+	})
+
+	t.Run("SortedBy", func(t *testing.T) {
+		sorted := SortedBy(people, byAge)
+		assert.Equal(t, []person{{"bob", 25}, {"ann", 30}, {"cat", 30}, {"ann", 41}}, sorted, "equal keys keep their order")
+		assert.Equal(t, person{"ann", 30}, people[0], "the input is not modified")
+	})
+
 	t.Run("ReverseOf", func(t *testing.T) {
 		a := []int{1, 3, 5, 7, 28}
 		b := ReverseOf(a)
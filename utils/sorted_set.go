@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"cmp"
+	"encoding/json"
+	"iter"
+	"slices"
+)
+
+// SortedSet is a Set whose ToSlice, All and MarshalJSON are in ascending order, so its output is deterministic.
+// The zero value is an empty set ready to use
+type SortedSet[T cmp.Ordered] struct {
+	Set[T]
+}
+
+func SortedSetFrom[T cmp.Ordered](ts ...T) SortedSet[T] {
+	return SortedSet[T]{SetOf(ts)}
+}
+
+// Add initialises the zero value before adding
+func (s *SortedSet[T]) Add(es ...T) {
+	if s.Set == nil {
+		s.Set = Set[T]{}
+	}
+	s.Set.Add(es...)
+}
+
+// Clone is Set.Clone, returning a SortedSet
+func (s SortedSet[T]) Clone() SortedSet[T] {
+	return SortedSet[T]{s.Set.Clone()}
+}
+
+// Union is Set.Union, returning a SortedSet
+func (s SortedSet[T]) Union(other SortedSet[T]) SortedSet[T] {
+	return SortedSet[T]{s.Set.Union(other.Set)}
+}
+
+// Intersect is Set.Intersect, returning a SortedSet
+func (s SortedSet[T]) Intersect(other SortedSet[T]) SortedSet[T] {
+	return SortedSet[T]{s.Set.Intersect(other.Set)}
+}
+
+// Difference is Set.Difference, returning a SortedSet
+func (s SortedSet[T]) Difference(other SortedSet[T]) SortedSet[T] {
+	return SortedSet[T]{s.Set.Difference(other.Set)}
+}
+
+// SymmetricDifference is Set.SymmetricDifference, returning a SortedSet
+func (s SortedSet[T]) SymmetricDifference(other SortedSet[T]) SortedSet[T] {
+	return SortedSet[T]{s.Set.SymmetricDifference(other.Set)}
+}
+
+func (s SortedSet[T]) IsSubset(other SortedSet[T]) bool {
+	return s.Set.IsSubset(other.Set)
+}
+
+func (s SortedSet[T]) Equal(other SortedSet[T]) bool {
+	return s.Set.Equal(other.Set)
+}
+
+func (s SortedSet[T]) ToSlice() []T {
+	sorted := s.Set.ToSlice()
+	slices.Sort(sorted)
+	return sorted
+}
+
+// All iterates the set's elements in ascending order
+func (s SortedSet[T]) All() iter.Seq[T] {
+	return slices.Values(s.ToSlice())
+}
+
+func (s SortedSet[T]) MarshalJSON() ([]byte, error) {
+	var res []T
+	if s.Set != nil {
+		res = s.ToSlice()
+	}
+	return json.Marshal(&res)
+}
@@ -0,0 +1,68 @@
+package utils
+
+import "sync"
+
+// SyncSet is a Set that is safe for concurrent use. The zero value is an empty set ready to use
+type SyncSet[T comparable] struct {
+	mut sync.RWMutex
+	set Set[T]
+}
+
+func SyncSetFrom[T comparable](ts ...T) *SyncSet[T] {
+	return &SyncSet[T]{set: SetOf(ts)}
+}
+
+func (s *SyncSet[T]) Add(es ...T) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	if s.set == nil {
+		s.set = Set[T]{}
+	}
+	s.set.Add(es...)
+}
+
+func (s *SyncSet[T]) Contains(el T) bool {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.set.Contains(el)
+}
+
+func (s *SyncSet[T]) Remove(el T) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.set.Remove(el)
+}
+
+func (s *SyncSet[T]) Len() int {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return len(s.set)
+}
+
+func (s *SyncSet[T]) ToSlice() []T {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.set.ToSlice()
+}
+
+// Snapshot returns a copy of the current elements, which later changes to s do not affect
+func (s *SyncSet[T]) Snapshot() Set[T] {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.set.Clone()
+}
+
+func (s *SyncSet[T]) MarshalJSON() ([]byte, error) {
+	return s.Snapshot().MarshalJSON()
+}
+
+func (s *SyncSet[T]) UnmarshalJSON(data []byte) error {
+	var set Set[T]
+	if err := set.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.set = set
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/bradfitz/gomemcache/memcache"
 	"github.com/reeceappling/goUtils/v2/io/awsclient"
+	"github.com/reeceappling/goUtils/v2/logging"
 	"github.com/reeceappling/goUtils/v2/utils"
 	"io"
 	"io/fs"
@@ -16,6 +17,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -75,7 +77,7 @@ func (cache EmptyMemcachedClient) Set(item *memcache.Item) error {
 }
 
 func SetEmptyMemcachedClient(ctx context.Context) context.Context {
-	return context.WithValue(ctx, awsclient.MemcachedClientKey, EmptyMemcachedClient{})
+	return awsclient.SetMemcachedClient(ctx, EmptyMemcachedClient{})
 }
 
 func SetLocalAWSClient(ctx context.Context) context.Context {
@@ -87,6 +89,17 @@ func SetLocalAWSClientWithRedirects(ctx context.Context, bucketRedirect map[stri
 	return SetEmptyMemcachedClient(ctx)
 }
 
+// SetTestLogger installs a logging.TestLogger for t in ctx, so what the code under test logs can be checked
+func SetTestLogger(t testing.TB, ctx context.Context) (context.Context, *logging.TestLogger) {
+	log := logging.NewTestLogger(t)
+	return log.Install(ctx), log
+}
+
+// SetLocalAWSClientWithLogger is SetLocalAWSClient that also installs a logging.TestLogger for t
+func SetLocalAWSClientWithLogger(t testing.TB, ctx context.Context) (context.Context, *logging.TestLogger) {
+	return SetTestLogger(t, SetLocalAWSClient(ctx))
+}
+
 type LocalS3Client struct {
 	BucketRedirect map[string]string
 }